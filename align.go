@@ -3,19 +3,18 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/termenv"
 )
 
 // Perform text alignment. If the string is multi-lined, we also make all lines
 // the same width by padding them with spaces. If a termenv style is passed,
 // use that to style the spaces added.
-func alignTextHorizontal(str string, pos Position, width int, style *termenv.Style) string {
-	lines, widestLine := getLines(str)
+func alignTextHorizontal(r *Renderer, str string, pos Position, width int, style *termenv.Style) string {
+	lines, widestLine := r.getLines(str)
 	var b strings.Builder
 
 	for i, l := range lines {
-		lineWidth := ansi.StringWidth(l)
+		lineWidth := r.stringWidth(l)
 
 		shortAmount := widestLine - lineWidth                // difference from the widest line
 		shortAmount += max(0, width-(shortAmount+lineWidth)) // difference from the total width, if set
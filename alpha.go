@@ -0,0 +1,84 @@
+package lipgloss
+
+import (
+	"strconv"
+
+	"github.com/muesli/termenv"
+)
+
+// WithAlpha returns the color with the given opacity, where alpha is between
+// 0 (fully transparent) and 1 (opaque). Terminals can't draw transparent
+// colors, so when rendering, the color is blended with the color underneath
+// it: for foreground colors that's the style's background, or the border
+// background for border colors, for background colors the margin background,
+// and otherwise the terminal's background.
+//
+// Colors can also be given an opacity with an 8 digit hex value, such as
+// Color("#ff000066"). The opacities of both are multiplied.
+//
+// The blending happens at render time. To get the blended color up front,
+// as it looks over the terminal's background, use [Alpha].
+func WithAlpha(c TerminalColor, alpha float64) TerminalColor {
+	return alphaColor{c, clamp(alpha, 0, 1)}
+}
+
+// alphaColor is a color with an opacity.
+type alphaColor struct {
+	c     TerminalColor
+	alpha float64
+}
+
+func (ac alphaColor) color(r *Renderer) termenv.Color {
+	return r.composite(ac, noColor).color(r)
+}
+
+// RGBA returns the RGBA value of this color, blended with the background of
+// the default renderer. This satisfies the Go Color interface.
+//
+// Deprecated.
+func (ac alphaColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(ac.color(DefaultRenderer())).RGBA()
+}
+
+// splitAlpha returns the opaque part of a color and its opacity. ok reports
+// whether the color has an opacity at all.
+func splitAlpha(c TerminalColor) (base TerminalColor, alpha float64, ok bool) {
+	switch c := c.(type) {
+	case alphaColor:
+		base, alpha, _ := splitAlpha(c.c)
+		return base, alpha * c.alpha, true
+	case Color:
+		var hex, a string
+		switch len(c) {
+		case 5: //nolint:mnd
+			hex, a = string(c[:4]), string(c[4:])+string(c[4:])
+		case 9: //nolint:mnd
+			hex, a = string(c[:7]), string(c[7:])
+		}
+		if hex == "" || c[0] != '#' {
+			break
+		}
+		v, err := strconv.ParseUint(a, 16, 8)
+		if err != nil {
+			break
+		}
+		return Color(hex), float64(v) / 255, true //nolint:mnd
+	}
+	return c, 1, false
+}
+
+// composite returns the color blended with the color under it, if it's
+// transparent. NoColor underneath stands for the terminal's background.
+// Opaque colors are returned as they are.
+func (r *Renderer) composite(c, under TerminalColor) TerminalColor {
+	base, alpha, ok := splitAlpha(c)
+	if !ok {
+		return c
+	}
+	fg, ok := r.resolveColor(base)
+	if !ok || alpha >= 1 {
+		return base
+	}
+	bg := r.resolveOrBackground(r.composite(under, noColor))
+	return Color(bg.BlendRgb(fg, alpha).Clamped().Hex())
+}
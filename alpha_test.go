@@ -0,0 +1,106 @@
+package lipgloss
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestTransparentColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		dark     bool
+		style    func(Style) Style
+		expected string
+	}{
+		{
+			name:     "foreground over terminal background",
+			dark:     true,
+			style:    func(s Style) Style { return s.Foreground(Color("#ffffff80")) },
+			expected: "\x1b[38;2;128;128;128mhi\x1b[0m",
+		},
+		{
+			name:     "light terminal background",
+			style:    func(s Style) Style { return s.Foreground(WithAlpha(Color("#000000"), 0.5)) },
+			expected: "\x1b[38;2;128;128;128mhi\x1b[0m",
+		},
+		{
+			name: "foreground over background",
+			dark: true,
+			style: func(s Style) Style {
+				return s.Foreground(WithAlpha(Color("#ff0000"), 0.5)).Background(Color("#0000ff"))
+			},
+			expected: "\x1b[38;2;128;0;128;48;2;0;0;255mhi\x1b[0m",
+		},
+		{
+			name: "background over margin background",
+			dark: true,
+			style: func(s Style) Style {
+				return s.Background(Color("#ff000080")).MarginBackground(Color("#0000ff"))
+			},
+			expected: "\x1b[48;2;128;0;127mhi\x1b[0m",
+		},
+		{
+			name: "nested transparency",
+			dark: true,
+			style: func(s Style) Style {
+				return s.Foreground(WithAlpha(Color("#ffffff"), 0.5)).Background(WithAlpha(Color("#ffffff"), 0.5))
+			},
+			expected: "\x1b[38;2;192;192;192;48;2;128;128;128mhi\x1b[0m",
+		},
+		{
+			name:     "opaque alpha",
+			dark:     true,
+			style:    func(s Style) Style { return s.Foreground(Color("#123456ff")) },
+			expected: "\x1b[38;2;18;52;86mhi\x1b[0m",
+		},
+		{
+			name:     "alphas multiply",
+			dark:     true,
+			style:    func(s Style) Style { return s.Foreground(WithAlpha(Color("#ffffff80"), 0.5)) },
+			expected: "\x1b[38;2;64;64;64mhi\x1b[0m",
+		},
+		{
+			name: "border foreground over border background",
+			dark: true,
+			style: func(s Style) Style {
+				return s.Border(Border{Left: "|"}, false, false, false, true).
+					BorderForeground(WithAlpha(Color("#ff0000"), 0.5)).
+					BorderBackground(Color("#0000ff"))
+			},
+			expected: "\x1b[38;2;128;0;128;48;2;0;0;255m|\x1b[0mhi",
+		},
+		{
+			name: "border background over margin background",
+			dark: true,
+			style: func(s Style) Style {
+				return s.Border(Border{Left: "|"}, false, false, false, true).
+					BorderBackground(Color("#ff000080")).
+					MarginBackground(Color("#0000ff"))
+			},
+			expected: "\x1b[48;2;128;0;127m|\x1b[0mhi",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(nil)
+			r.SetColorProfile(termenv.TrueColor)
+			r.SetHasDarkBackground(tc.dark)
+			if res := tc.style(r.NewStyle()).Render("hi"); res != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+
+	t.Run("queried background", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.TrueColor)
+		r.SetTerminalColors(TerminalColors{Background: color.RGBA{0x28, 0x2a, 0x36, 0xff}})
+		res := r.NewStyle().Foreground(WithAlpha(Color("#282a36"), 0.3)).Render("hi")
+		if expected := "\x1b[38;2;40;42;54mhi\x1b[0m"; res != expected {
+			t.Errorf("expected %q, got %q", expected, res)
+		}
+	})
+}
@@ -2,15 +2,18 @@ package lipgloss
 
 import (
 	"strings"
-	"unicode/utf8"
 
 	"github.com/charmbracelet/x/ansi"
-	"github.com/clipperhouse/displaywidth"
 	"github.com/muesli/termenv"
 )
 
 // Border contains a series of values which comprise the various parts of a
 // border.
+//
+// Corners may be several cells wide. The left and right sides are laid out
+// vertically, one rune per row, and repeated. A side containing newlines is
+// split into rows at them instead, so that rows can be several cells wide:
+// "[ \n" is a side whose rows are all "[ ".
 type Border struct {
 	Top          string
 	Bottom       string
@@ -116,6 +119,152 @@ type BorderDecoration struct {
 	side  BorderSide
 	align Position
 	st    interface{}
+
+	priority int
+	ellipsis string
+	brackets [2]string
+	padding  int
+}
+
+// Priority sets the priority of the decoration. When a top or bottom border
+// is too narrow for all of its decorations, the ones with the lowest priority
+// are shortened first, until they're elided entirely, before the ones with a
+// higher priority are. Among decorations of equal priority, the widest is
+// shortened first. The default priority is 0.
+//
+// Priorities only apply to the top and bottom edges. Decorations on the left
+// and right edges are cut off to fit, whatever their priority.
+func (d BorderDecoration) Priority(p int) BorderDecoration {
+	d.priority = p
+	return d
+}
+
+// Ellipsis sets the string that ends the decoration when it's shortened to
+// fit a top or bottom border, such as "…". By default shortened decorations
+// are cut off. Decorations on the left and right edges are always cut off
+// without an ellipsis.
+func (d BorderDecoration) Ellipsis(e string) BorderDecoration {
+	d.ellipsis = e
+	return d
+}
+
+// BorderTitleOption configures a title set with Style.BorderTitle or
+// Style.BorderFooter.
+type BorderTitleOption func(*BorderDecoration)
+
+// WithTitlePadding sets the number of spaces on each side of the title. The
+// default is 1.
+func WithTitlePadding(n int) BorderTitleOption {
+	return func(d *BorderDecoration) {
+		d.padding = max(0, n)
+	}
+}
+
+// WithTitleBrackets sets the strings the title and its padding are framed
+// with, such as "┤" and "├". They're drawn with the border's colors and are
+// kept when the title is shortened.
+func WithTitleBrackets(left, right string) BorderTitleOption {
+	return func(d *BorderDecoration) {
+		d.brackets = [2]string{left, right}
+	}
+}
+
+// WithTitlePriority sets the priority of the title. See
+// BorderDecoration.Priority.
+func WithTitlePriority(p int) BorderTitleOption {
+	return func(d *BorderDecoration) {
+		d.priority = p
+	}
+}
+
+// WithTitleEllipsis sets the string that ends the title when it's shortened.
+// The default is "…".
+func WithTitleEllipsis(e string) BorderTitleOption {
+	return func(d *BorderDecoration) {
+		d.ellipsis = e
+	}
+}
+
+// newBorderTitle returns a decoration showing title on the given side.
+func newBorderTitle(side BorderSide, align Position, title string, opts ...BorderTitleOption) BorderDecoration {
+	d := NewBorderDecoration(side, align, title)
+	d.padding = 1
+	d.ellipsis = "…"
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// frameWidth returns the width of the brackets and padding around the
+// decoration.
+func (d BorderDecoration) frameWidth(r *Renderer) int {
+	return r.stringWidth(d.brackets[0]) + r.stringWidth(d.brackets[1]) + 2*d.padding
+}
+
+// fit returns the decoration with the given content, shortened to fit width
+// cells, and framed with its brackets and padding.
+func (d BorderDecoration) fit(r *Renderer, content string, width int, styleBorder func(string) string) string {
+	width -= d.frameWidth(r)
+	if r.stringWidth(content) > width {
+		tail := r.truncate(d.ellipsis, width)
+		content = r.truncate(content, width-r.stringWidth(tail)) + tail
+	}
+
+	left, right := d.brackets[0], d.brackets[1]
+	if left != "" {
+		left = styleBorder(left)
+	}
+	if right != "" {
+		right = styleBorder(right)
+	}
+	pad := strings.Repeat(" ", d.padding)
+	return left + pad + content + pad + right
+}
+
+// fitDecorations returns the widths given to the left, center and right
+// decorations of an edge, so that they fit in length cells without touching.
+// Until they fit, the decoration with the lowest priority is shortened, and
+// elided once it's narrower than its minimum width. Among decorations of
+// equal priority and width, the center one is shortened first.
+func fitDecorations(widths, minWidths, priorities [3]int, length int) [3]int {
+	for !decorationsFit(widths, length) {
+		i := -1
+		for _, j := range [3]int{1, 0, 2} {
+			if widths[j] == 0 {
+				continue
+			}
+			if i < 0 || priorities[j] < priorities[i] || (priorities[j] == priorities[i] && widths[j] > widths[i]) {
+				i = j
+			}
+		}
+		if i < 0 {
+			break
+		}
+		widths[i]--
+		if widths[i] < minWidths[i] {
+			widths[i] = 0
+		}
+	}
+	return widths
+}
+
+// decorationsFit reports whether decorations of the given widths fit on an
+// edge length cells long: the left one after the left corner, the center one
+// centered, and the right one before the right corner, with at least one
+// cell between each of them.
+func decorationsFit(widths [3]int, length int) bool {
+	left, center, right := widths[0], widths[1], widths[2]
+	if center == 0 {
+		if left == 0 || right == 0 {
+			return left+right <= length
+		}
+		return left+right < length
+	}
+	start := (length - center) / 2
+	return center <= length &&
+		(left == 0 || left < start) &&
+		(right == 0 || start+center < length-right)
 }
 
 // BorderDecorator is constraint type for a string or function that is used
@@ -166,39 +315,72 @@ func NewBorderDecoration[S BorderDecorator](side BorderSide, align Position, st
 	}
 }
 
-// GetTopSize returns the width of the top border. If borders contain runes of
-// varying widths, the widest rune is returned. If no border exists on the top
-// edge, 0 is returned.
+// GetTopSize returns the height of the top border: 1 if any part of it is
+// set, or 0 if no border exists on the top edge.
 func (b Border) GetTopSize() int {
-	return getBorderEdgeWidth(b.TopLeft, b.Top, b.TopRight)
+	return b.topSize(DefaultRenderer())
 }
 
-// GetRightSize returns the width of the right border. If borders contain
-// runes of varying widths, the widest rune is returned. If no border exists on
-// the right edge, 0 is returned.
+// GetRightSize returns the width of the right border. Corners and sides may
+// be several cells wide, in which case the widest part is returned. If no
+// border exists on the right edge, 0 is returned.
 func (b Border) GetRightSize() int {
-	return getBorderEdgeWidth(b.TopRight, b.Right, b.BottomRight)
+	return b.rightSize(DefaultRenderer())
 }
 
-// GetBottomSize returns the width of the bottom border. If borders contain
-// runes of varying widths, the widest rune is returned. If no border exists on
-// the bottom edge, 0 is returned.
+// GetBottomSize returns the height of the bottom border: 1 if any part of it
+// is set, or 0 if no border exists on the bottom edge.
 func (b Border) GetBottomSize() int {
-	return getBorderEdgeWidth(b.BottomLeft, b.Bottom, b.BottomRight)
+	return b.bottomSize(DefaultRenderer())
 }
 
-// GetLeftSize returns the width of the left border. If borders contain runes
-// of varying widths, the widest rune is returned. If no border exists on the
-// left edge, 0 is returned.
+// GetLeftSize returns the width of the left border. Corners and sides may be
+// several cells wide, in which case the widest part is returned. If no border
+// exists on the left edge, 0 is returned.
 func (b Border) GetLeftSize() int {
-	return getBorderEdgeWidth(b.TopLeft, b.Left, b.BottomLeft)
+	return b.leftSize(DefaultRenderer())
+}
+
+// Edge sizes measured with the given renderer's width method.
+func (b Border) topSize(r *Renderer) int {
+	return min(1, getBorderEdgeWidth(r, b.TopLeft, b.Top, b.TopRight))
+}
+
+func (b Border) rightSize(r *Renderer) int {
+	return getBorderEdgeWidth(r, b.TopRight, b.Right, b.BottomRight)
+}
+
+func (b Border) bottomSize(r *Renderer) int {
+	return min(1, getBorderEdgeWidth(r, b.BottomLeft, b.Bottom, b.BottomRight))
+}
+
+func (b Border) leftSize(r *Renderer) int {
+	return getBorderEdgeWidth(r, b.TopLeft, b.Left, b.BottomLeft)
+}
+
+// getBorderEdgeWidth returns the width of the widest part of a border edge:
+// either corner, or the widest row of the side between them.
+func getBorderEdgeWidth(r *Renderer, startCorner, side, endCorner string) int {
+	width := max(r.stringWidth(startCorner), r.stringWidth(endCorner))
+	for _, row := range borderSideRows(side) {
+		width = max(width, r.stringWidth(row))
+	}
+	return width
 }
 
-func getBorderEdgeWidth(borderParts ...string) (maxWidth int) {
-	for _, piece := range borderParts {
-		maxWidth = max(maxWidth, maxRuneWidth(piece))
+// borderSideRows returns the rows a side border repeats, top to bottom. A
+// side containing newlines is split into rows at them, so rows can be several
+// cells wide; a single trailing newline is ignored, so "[ \n" is a side made
+// of one "[ " row. Otherwise each rune of the side is a row.
+func borderSideRows(side string) []string {
+	if strings.Contains(side, "\n") {
+		return strings.Split(strings.TrimSuffix(side, "\n"), "\n")
+	}
+	rows := make([]string, 0, len(side))
+	for _, r := range side {
+		rows = append(rows, string(r))
 	}
-	return maxWidth
+	return rows
 }
 
 var (
@@ -417,7 +599,7 @@ func ASCIIBorder() Border {
 
 func (s Style) applyBorder(str string) string {
 	var (
-		border    = s.getBorderStyle()
+		border    = s.getRenderedBorderStyle()
 		hasTop    = s.getAsBool(borderTopKey, false)
 		hasRight  = s.getAsBool(borderRightKey, false)
 		hasBottom = s.getAsBool(borderBottomKey, false)
@@ -453,13 +635,10 @@ func (s Style) applyBorder(str string) string {
 		return str
 	}
 
-	lines, width := getLines(str)
+	lines, width := s.r.getLines(str)
 
-	if hasLeft {
-		if border.Left == "" {
-			border.Left = " "
-		}
-		// width += maxRuneWidth(border.Left)
+	if hasLeft && border.Left == "" {
+		border.Left = " "
 	}
 
 	if hasRight && border.Right == "" {
@@ -506,11 +685,18 @@ func (s Style) applyBorder(str string) string {
 		}
 	}
 
-	// For now, limit corners to one rune.
-	border.TopLeft = getFirstRuneAsString(border.TopLeft)
-	border.TopRight = getFirstRuneAsString(border.TopRight)
-	border.BottomRight = getFirstRuneAsString(border.BottomRight)
-	border.BottomLeft = getFirstRuneAsString(border.BottomLeft)
+	// Corners and sides may be several cells wide. Each side is as wide as
+	// its widest part: narrower corners are extended with the top and bottom
+	// edges, and narrower sides are padded with spaces.
+	leftWidth, rightWidth := border.leftSize(s.r), border.rightSize(s.r)
+	if hasLeft {
+		border.TopLeft = padCorner(s.r, border.TopLeft, border.Top, leftWidth, Left)
+		border.BottomLeft = padCorner(s.r, border.BottomLeft, border.Bottom, leftWidth, Left)
+	}
+	if hasRight {
+		border.TopRight = padCorner(s.r, border.TopRight, border.Top, rightWidth, Right)
+		border.BottomRight = padCorner(s.r, border.BottomRight, border.Bottom, rightWidth, Right)
+	}
 
 	var out strings.Builder
 
@@ -525,6 +711,7 @@ func (s Style) applyBorder(str string) string {
 				border.TopRight,
 				topFuncs,
 				width,
+				s.r,
 				topFG,
 				topBG,
 				s.styleBorder,
@@ -535,6 +722,7 @@ func (s Style) applyBorder(str string) string {
 				border.Top,
 				border.TopRight,
 				width,
+				s.r,
 			)
 		}
 		// top = renderHorizontalEdge(border.TopLeft, border.Top, border.TopRight, width)
@@ -543,53 +731,47 @@ func (s Style) applyBorder(str string) string {
 		out.WriteRune('\n')
 	}
 
-	// leftRunes := []rune(border.Left)
-	// leftIndex := 0
-
 	leftBorder := make([]string, len(lines))
 	rightBorder := make([]string, len(lines))
 
+	// Sides are repeated on every line, one row per line: a rune each, or a
+	// whole row if the side is split into rows with newlines.
 	if hasLeft {
-		leftRunes := make([]rune, 0, len(lines))
-		for len(leftRunes) < len(lines) {
-			left := []rune(border.Left)
-			leftRunes = append(leftRunes, left...)
-		}
-		leftRunes = leftRunes[:len(lines)]
-		for i := range leftRunes {
-			leftBorder[i] = string(leftRunes[i])
+		left := borderSideRows(border.Left)
+		for i := range leftBorder {
+			leftBorder[i] = left[i%len(left)]
 		}
 		if len(leftFuncs) > 0 {
 			leftBorder = renderVerticalEdge(
+				s.r,
 				leftBorder,
 				border.Left,
 				leftFuncs,
 			)
 		}
+		for i, l := range leftBorder {
+			leftBorder[i] = l + strings.Repeat(" ", max(0, leftWidth-s.r.stringWidth(l)))
+		}
 	}
 
 	if hasRight {
-		rightRunes := make([]rune, 0, len(lines))
-		right := []rune(border.Right)
-		for len(rightRunes) < len(lines) {
-			rightRunes = append(rightRunes, right...)
-		}
-		rightRunes = rightRunes[:len(lines)]
-		for i := range rightRunes {
-			rightBorder[i] = string(rightRunes[i])
+		right := borderSideRows(border.Right)
+		for i := range rightBorder {
+			rightBorder[i] = right[i%len(right)]
 		}
 		if len(rightFuncs) > 0 {
 			rightBorder = renderVerticalEdge(
+				s.r,
 				rightBorder,
 				border.Right,
 				rightFuncs,
 			)
 		}
+		for i, r := range rightBorder {
+			rightBorder[i] = strings.Repeat(" ", max(0, rightWidth-s.r.stringWidth(r))) + r
+		}
 	}
 
-	// rightRunes := []rune(border.Right)
-	// rightIndex := 0
-
 	// Render sides
 	for i, l := range lines {
 		if i > 0 {
@@ -614,12 +796,13 @@ func (s Style) applyBorder(str string) string {
 				border.BottomRight,
 				bottomFuncs,
 				width,
+				s.r,
 				bottomFG,
 				bottomBG,
 				s.styleBorder,
 			)
 		} else {
-			bottom = renderHorizontalEdge(border.BottomLeft, border.Bottom, border.BottomRight, width)
+			bottom = renderHorizontalEdge(border.BottomLeft, border.Bottom, border.BottomRight, width, s.r)
 		}
 		// bottom := renderHorizontalEdge(border.BottomLeft, border.Bottom, border.BottomRight, width)
 		bottom = s.styleBorder(bottom, bottomFG, bottomBG)
@@ -674,7 +857,10 @@ func truncateWidths(leftWidth, centerWidth, rightWidth, length int) (int, int, i
 	return leftWidth, centerWidth, rightWidth
 }
 
-func renderVerticalEdge(edge []string, middle string, bFuncs []interface{}) []string {
+// renderVerticalEdge places the decorations of a left or right edge on its
+// rows. Unlike on the top and bottom edges, they're cut off to fit with
+// truncateWidths, ignoring their priority and ellipsis.
+func renderVerticalEdge(r *Renderer, edge []string, middle string, bFuncs []interface{}) []string {
 	height := len(edge)
 
 	var transformer func(int, int, string) string
@@ -686,10 +872,11 @@ func renderVerticalEdge(edge []string, middle string, bFuncs []interface{}) []st
 	// the width.
 	{
 		for i, f := range bFuncs {
-			if f == nil {
+			d, ok := f.(BorderDecoration)
+			if !ok {
 				continue
 			}
-			switch f := f.(type) {
+			switch f := d.st.(type) {
 			case string:
 				ts[i] = f
 			case func() string:
@@ -699,11 +886,11 @@ func renderVerticalEdge(edge []string, middle string, bFuncs []interface{}) []st
 			case func(int, int, string) string:
 				transformer = f
 			}
-			ws[i] = ansi.StringWidth(ts[i])
+			ws[i] = r.stringWidth(ts[i])
 		}
 		ws[0], ws[1], ws[2] = truncateWidths(ws[0], ws[1], ws[2], height)
 		for i := range ts {
-			ts[i] = ansi.Truncate(ts[i], ws[i], "")
+			ts[i] = r.truncate(ts[i], ws[i])
 		}
 	}
 
@@ -720,9 +907,9 @@ func renderVerticalEdge(edge []string, middle string, bFuncs []interface{}) []st
 	if transformer != nil {
 		// transform
 		for i := range edge {
-			w := ansi.StringWidth(edge[i])
+			w := r.stringWidth(edge[i])
 			edge[i] = transformer(i, height, edge[i])
-			edge[i] = ansi.Truncate(edge[i], w, "")
+			edge[i] = r.truncate(edge[i], w)
 		}
 	}
 
@@ -733,105 +920,105 @@ func renderAnnotatedHorizontalEdge(
 	left, middle, right string,
 	bFuncs []interface{},
 	width int,
+	r *Renderer,
 	borderFG, borderBG TerminalColor,
 	styleBorderFunc func(string, TerminalColor, TerminalColor) string,
 ) string {
 	if middle == "" {
 		middle = " "
 	}
+	styleBorder := func(s string) string {
+		return styleBorderFunc(s, borderFG, borderBG)
+	}
 
-	//Extract decoration content
-	decorations := make([]string, 3)
+	// Extract decoration content
+	var (
+		decorations [3]BorderDecoration
+		contents    [3]string
+		widths      [3]int
+		minWidths   [3]int
+		priorities  [3]int
+	)
 	for i, f := range bFuncs {
-		if f == nil {
+		d, ok := f.(BorderDecoration)
+		if !ok {
 			continue
 		}
-		switch f := f.(type) {
+		switch st := d.st.(type) {
 		case string:
-			decorations[i] = f
+			contents[i] = st
 		case func() string:
-			decorations[i] = f()
+			contents[i] = st()
 		case func(int, string) string:
-			decorations[i] = f(width, middle)
+			contents[i] = st(width, middle)
 		}
+		if contents[i] == "" {
+			continue
+		}
+		decorations[i] = d
+		widths[i] = d.frameWidth(r) + r.stringWidth(contents[i])
+		minWidths[i] = d.frameWidth(r) + max(1, r.stringWidth(d.ellipsis))
+		priorities[i] = d.priority
 	}
 
-	leftDecor := decorations[0]
-	centerDecor := decorations[1]
-	rightDecor := decorations[2]
-
-	// Calculate visual widths
-	leftWidth := ansi.StringWidth(leftDecor)
-	centerWidth := ansi.StringWidth(centerDecor)
-	rightWidth := ansi.StringWidth(rightDecor)
-
-	// Calculate positions
-	// Position 0: left corner
-	// Position width-1: right corner
-	// Left decoration: positions [1, leftWidth]
-	// Center decoration: positions [centerStart, centerStart + centerWidth]
-	// Right decoration: positions [width-1-rightWidth, width-1)
-	centerStart := (width - centerWidth) / 2
-	rightStart := width - 1 - rightWidth
-	middleRune := []rune(middle)[0]
-
-	var result strings.Builder
-
-	// Position 0: left corner
-	result.WriteString(styleBorderFunc(left, borderFG, borderBG))
-
-	// Left decoration
-	if leftWidth > 0 {
-		result.WriteString(leftDecor)
-	}
-
-	// Middle section from after left decoration to center decoration
-	for i := leftWidth; i < centerStart; i++ {
-		result.WriteString(styleBorderFunc(string(middleRune), borderFG, borderBG))
+	// Shorten the decorations that don't fit, and measure what's left of
+	// them.
+	widths = fitDecorations(widths, minWidths, priorities, width)
+	for i, d := range decorations {
+		if widths[i] == 0 {
+			contents[i] = ""
+			continue
+		}
+		contents[i] = d.fit(r, contents[i], widths[i], styleBorder)
+		widths[i] = r.stringWidth(contents[i])
 	}
 
-	// Center decoration (render as-is, don't modify styling)
-	if centerWidth > 0 {
-		result.WriteString(centerDecor)
+	// The left decoration follows the left corner, the center one is
+	// centered, and the right one precedes the right corner. The rest is
+	// filled with the edge.
+	var leftFill int
+	if widths[1] > 0 {
+		leftFill = max(0, (width-widths[1])/2-widths[0])
 	}
+	rightFill := max(0, width-widths[0]-leftFill-widths[1]-widths[2])
 
-	// Middle section from after center decoration to right decoration
-	for i := centerStart + centerWidth; i <= rightStart; i++ {
-		result.WriteString(styleBorderFunc(string(middleRune), borderFG, borderBG))
+	var result strings.Builder
+	result.WriteString(styleBorder(left))
+	result.WriteString(contents[0])
+	if leftFill > 0 {
+		result.WriteString(styleBorder(repeatToWidth(r, middle, leftFill)))
 	}
-
-	// Right decoration
-	if rightWidth > 0 {
-		result.WriteString(rightDecor)
+	result.WriteString(contents[1])
+	if rightFill > 0 {
+		result.WriteString(styleBorder(repeatToWidth(r, middle, rightFill)))
 	}
-
-	// Position width-1: right corner
-	result.WriteString(styleBorderFunc(right, borderFG, borderBG))
+	result.WriteString(contents[2])
+	result.WriteString(styleBorder(right))
 	return result.String()
 }
 
 // Render the horizontal (top or bottom) portion of a border.
-func renderHorizontalEdge(left, middle, right string, width int) string {
+func renderHorizontalEdge(left, middle, right string, width int, r *Renderer) string {
 	if middle == "" {
 		middle = " "
 	}
+	return left + repeatToWidth(r, middle, width) + right
+}
 
-	runes := []rune(middle)
-	j := 0
-
-	out := strings.Builder{}
-	out.WriteString(left)
-	for i := 0; i < width; {
-		out.WriteRune(runes[j])
-		j++
-		if j >= len(runes) {
-			j = 0
-		}
-		i += ansi.StringWidth(string(runes[j]))
+// padCorner extends a corner that's narrower than the side it belongs to with
+// the edge it joins, on the side facing the edge.
+func padCorner(r *Renderer, corner, edge string, width int, pos Position) string {
+	n := width - r.stringWidth(corner)
+	if n <= 0 {
+		return corner
 	}
-	out.WriteString(right)
-
-	return out.String()
+	if edge == "" {
+		edge = " "
+	}
+	if pos == Left {
+		return corner + repeatToWidth(r, edge, n)
+	}
+	return repeatToWidth(r, edge, n) + corner
 }
 
 // Apply foreground and background styling to a border.
@@ -840,6 +1027,12 @@ func (s Style) styleBorder(border string, fg, bg TerminalColor) string {
 		return border
 	}
 
+	// Transparent colors are blended with the color underneath, as they are
+	// for the content: the border's background with the margin background,
+	// and its foreground with the border's background.
+	bg = s.r.composite(bg, s.getAsColor(marginBackgroundKey))
+	fg = s.r.composite(fg, bg)
+
 	style := termenv.Style{}
 
 	if fg != noColor {
@@ -852,31 +1045,6 @@ func (s Style) styleBorder(border string, fg, bg TerminalColor) string {
 	return style.Styled(border)
 }
 
-func maxRuneWidth(str string) int {
-	switch len(str) {
-	case 0:
-		return 0
-	case 1:
-		return displaywidth.String(str)
-	}
-
-	var width int
-
-	g := displaywidth.StringGraphemes(str)
-	for g.Next() {
-		width = max(width, g.Width())
-	}
-	return width
-}
-
-func getFirstRuneAsString(str string) string {
-	if str == "" {
-		return str
-	}
-	_, size := utf8.DecodeRuneInString(str)
-	return str[:size]
-}
-
 // Alternative: Split styled string character by character, preserving ANSI codes
 func splitStyledString(s string) []string {
 	if s == "" {
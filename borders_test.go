@@ -1,9 +1,8 @@
 package lipgloss
 
 import (
+	"strings"
 	"testing"
-
-	"github.com/rivo/uniseg"
 )
 
 func TestStyle_GetBorderSizes(t *testing.T) {
@@ -67,6 +66,21 @@ func TestStyle_GetBorderSizes(t *testing.T) {
 			wantX: 1, // left and right borders are laid out vertically, one rune per row
 			wantY: 0,
 		},
+		{
+			name: "Multi-cell corners and sides",
+			style: NewStyle().Border(Border{
+				Top: "─", Bottom: "─", Left: "[ \n", Right: " ]\n",
+				TopLeft: "╭─", TopRight: "─╮", BottomLeft: "╰", BottomRight: "╯",
+			}),
+			wantX: 4,
+			wantY: 2,
+		},
+		{
+			name:  "Wide border glyphs",
+			style: NewStyle().Border(Border{Left: "🟦", Right: "🟦", Top: "🟦", Bottom: "🟦"}),
+			wantX: 4,
+			wantY: 2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,129 +113,6 @@ func TestStyle_GetBorderSizes(t *testing.T) {
 	}
 }
 
-// Old implementation using rune slice conversion
-func getFirstRuneAsStringOld(str string) string {
-	if str == "" {
-		return str
-	}
-	r := []rune(str)
-	return string(r[0])
-}
-
-func TestGetFirstRuneAsString(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  string
-	}{
-		{"Empty", "", ""},
-		{"SingleASCII", "A", "A"},
-		{"SingleUnicode", "世", "世"},
-		{"ASCIIString", "Hello", "H"},
-		{"UnicodeString", "你好世界", "你"},
-		{"MixedASCIIFirst", "Hello世界", "H"},
-		{"MixedUnicodeFirst", "世界Hello", "世"},
-		{"Emoji", "😀Happy", "😀"},
-		{"MultiByteFirst", "ñoño", "ñ"},
-		{"LongString", "The quick brown fox jumps over the lazy dog", "T"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := getFirstRuneAsString(tt.input)
-			if got != tt.want {
-				t.Errorf("getFirstRuneAsString(%q) = %q, want %q", tt.input, got, tt.want)
-			}
-
-			// Verify new implementation matches old implementation
-			old := getFirstRuneAsStringOld(tt.input)
-			if got != old {
-				t.Errorf("getFirstRuneAsString(%q) = %q, but old implementation returns %q", tt.input, got, old)
-			}
-		})
-	}
-}
-
-func BenchmarkGetFirstRuneAsString(b *testing.B) {
-	testCases := []struct {
-		name string
-		str  string
-	}{
-		{"ASCII", "Hello, World!"},
-		{"Unicode", "你好世界"},
-		{"Single", "A"},
-		{"Empty", ""},
-	}
-
-	b.Run("Old", func(b *testing.B) {
-		for _, tc := range testCases {
-			b.Run(tc.name, func(b *testing.B) {
-				b.ReportAllocs()
-				for i := 0; i < b.N; i++ {
-					_ = getFirstRuneAsStringOld(tc.str)
-				}
-			})
-		}
-	})
-
-	b.Run("New", func(b *testing.B) {
-		for _, tc := range testCases {
-			b.Run(tc.name, func(b *testing.B) {
-				b.ReportAllocs()
-				for i := 0; i < b.N; i++ {
-					_ = getFirstRuneAsString(tc.str)
-				}
-			})
-		}
-	})
-}
-
-func BenchmarkMaxRuneWidth(b *testing.B) {
-	testCases := []struct {
-		name string
-		str  string
-	}{
-		{"Blank", " "},
-		{"ASCII", "+"},
-		{"Markdown", "|"},
-		{"Normal", "├"},
-		{"Rounded", "╭"},
-		{"Block", "█"},
-		{"Emoji", "😀"},
-	}
-	for _, tc := range testCases {
-		b.Run(tc.name, func(b *testing.B) {
-			b.Run("Before", func(b *testing.B) {
-				b.ReportAllocs()
-				for b.Loop() {
-					_ = maxRuneWidthOld(tc.str)
-				}
-			})
-			b.Run("After", func(b *testing.B) {
-				b.ReportAllocs()
-				for b.Loop() {
-					_ = maxRuneWidth(tc.str)
-				}
-			})
-		})
-	}
-}
-
-func maxRuneWidthOld(str string) int {
-	var width int
-
-	state := -1
-	for len(str) > 0 {
-		var w int
-		_, str, w, state = uniseg.FirstGraphemeClusterInString(str, state)
-		if w > width {
-			width = w
-		}
-	}
-
-	return width
-}
-
 func TestBorderFunc(t *testing.T) {
 
 	tt := []struct {
@@ -573,3 +464,157 @@ func TestSplitStyledString(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiCellBorders(t *testing.T) {
+	tt := []struct {
+		name     string
+		border   Border
+		expected string
+	}{
+		{
+			name: "multi-cell corners",
+			border: Border{
+				Top: "─", Bottom: "─", Left: "│", Right: "│",
+				TopLeft: "╭─", TopRight: "─╮", BottomLeft: "╰─", BottomRight: "─╯",
+			},
+			expected: "╭───────╮\n│ hello │\n│ world │\n╰───────╯",
+		},
+		{
+			name: "multi-cell sides",
+			border: Border{
+				Top: "-", Bottom: "-", Left: "[ \n", Right: " ]\n",
+				TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+			},
+			expected: "+-------+\n[ hello ]\n[ world ]\n+-------+",
+		},
+		{
+			name: "side patterns",
+			border: Border{
+				Left: "|\n:", Right: "|",
+			},
+			expected: "|hello|\n:world|",
+		},
+		{
+			name: "one rune per row",
+			border: Border{
+				Left: "|:", Right: "[]",
+			},
+			expected: "|hello[\n:world]",
+		},
+		{
+			name: "multi-cell side patterns",
+			border: Border{
+				Left: "[ \n:", Right: "|",
+			},
+			expected: "[ hello|\n: world|",
+		},
+		{
+			name: "wide glyphs",
+			border: Border{
+				Top: "🟦", Bottom: "🟦", Left: "🟦", Right: "🟦",
+				TopLeft: "🟦", TopRight: "🟦", BottomLeft: "🟦", BottomRight: "🟦",
+			},
+			expected: "🟦🟦🟦 🟦\n🟦hello🟦\n🟦world🟦\n🟦🟦🟦 🟦",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			style := NewStyle().Border(tc.border)
+			if tc.border.Top == "" {
+				style = style.BorderTop(false).BorderBottom(false).BorderLeft(true).BorderRight(true)
+			}
+			res := style.Render("hello\nworld")
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s\n\n", tc.expected, res)
+			}
+
+			w, h := Size(res)
+			if fw, fh := style.GetFrameSize(); w != fw+5 || h != fh+2 {
+				t.Errorf("frame size (%d, %d) doesn't match rendered size (%d, %d)", fw, fh, w, h)
+			}
+			for _, line := range strings.Split(res, "\n") {
+				if Width(line) != w {
+					t.Errorf("expected every line to be %d cells wide, got %q", w, line)
+				}
+			}
+		})
+	}
+}
+
+func TestBorderTitles(t *testing.T) {
+	tt := []struct {
+		name     string
+		style    Style
+		expected string
+	}{
+		{
+			name:     "centered title",
+			style:    NewStyle().BorderTitle("Title", Center),
+			expected: "┌── Title ──┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name:     "brackets",
+			style:    NewStyle().BorderTitle("Title", Left, WithTitleBrackets("┤", "├")),
+			expected: "┌┤ Title ├──┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name:     "footer",
+			style:    NewStyle().BorderFooter("ok", Right, WithTitlePadding(0)),
+			expected: "┌───────────┐\n│hello world│\n└─────────ok┘",
+		},
+		{
+			name:     "right title wider than half the edge",
+			style:    NewStyle().BorderTitle("status", Right),
+			expected: "┌─── status ┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name:     "right footer wider than half the edge",
+			style:    NewStyle().BorderFooter("status line", Right),
+			expected: "┌───────────┐\n│hello world│\n└ status l… ┘",
+		},
+		{
+			name: "lowest priority is shortened first",
+			style: NewStyle().
+				BorderTitle("Long title", Left).
+				BorderTitle("1/3", Right, WithTitlePriority(1)),
+			expected: "┌ Lo… ─ 1/3 ┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name: "lowest priority is elided",
+			style: NewStyle().
+				BorderTitle("Long title", Left, WithTitlePriority(1)).
+				BorderTitle("1/3", Right),
+			expected: "┌ Long tit… ┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name: "decoration ellipsis",
+			style: NewStyle().BorderDecoration(
+				NewBorderDecoration(BorderTop, Center, "abcdefghijklmnop").Ellipsis("…"),
+			),
+			expected: "┌abcdefghij…┐\n│hello world│\n└───────────┘",
+		},
+		{
+			name: "decorations are cut off by default",
+			style: NewStyle().BorderDecoration(
+				NewBorderDecoration(BorderTop, Left, "abcdefghijklmnop"),
+			),
+			expected: "┌abcdefghijk┐\n│hello world│\n└───────────┘",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.style.Border(NormalBorder()).Render("hello world")
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s\n\n", tc.expected, res)
+			}
+		})
+	}
+
+	// The edges are as wide as the box, whatever the decorations.
+	res := NewStyle().Border(NormalBorder()).Width(12).BorderFooter("status line", Right).Render("")
+	if expected := "┌────────────┐\n│            │\n└ status li… ┘"; res != expected {
+		t.Errorf("Expected:\n\n%s\n\nActual Output:\n\n%s\n\n", expected, res)
+	}
+}
@@ -0,0 +1,220 @@
+package lipgloss
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Capabilities describes what a terminal supports, beyond its color profile.
+type Capabilities struct {
+	// Italic reports whether italic text is supported.
+	Italic bool
+
+	// Strikethrough reports whether crossed out text is supported.
+	Strikethrough bool
+
+	// Hyperlinks reports whether OSC 8 hyperlinks are supported.
+	Hyperlinks bool
+
+	// StyledUnderlines reports whether curly, dotted and dashed underlines,
+	// and underline colors, are supported.
+	StyledUnderlines bool
+
+	// SynchronizedOutput reports whether synchronized output (mode 2026) is
+	// supported.
+	SynchronizedOutput bool
+
+	// GraphemeWidth reports whether grapheme clustering (mode 2027) is
+	// supported, which means text is laid out like the GraphemeWidth width
+	// method measures it.
+	GraphemeWidth bool
+
+	// UnicodeBoxDrawing reports whether the Unicode box drawing characters
+	// used by most borders can be displayed.
+	UnicodeBoxDrawing bool
+}
+
+// Capabilities returns what the renderer's terminal supports. Renderers
+// created with NewRendererFromEnv guess them from environment variables such
+// as TERM, TERM_PROGRAM and LANG. Other renderers assume italic and crossed
+// out text and box drawing characters are supported, but none of the more
+// recent features, unless told otherwise with SetCapabilities or
+// DetectCapabilities.
+//
+// To draw ASCII borders regardless of the terminal, see SetASCIIBorders.
+//
+// Styles rendered with the renderer leave out the text attributes the
+// terminal doesn't support, and borders fall back to ASCII characters when
+// box drawing characters can't be displayed.
+func (r *Renderer) Capabilities() Capabilities {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if !r.explicitCapabilities {
+		r.getCapabilities.Do(func() {
+			// NOTE: we don't need to lock here because sync.Once provides its
+			// own locking mechanism.
+			if r.environ != nil {
+				r.capabilities = envCapabilities(r.environ)
+			} else {
+				r.capabilities = defaultCapabilities
+			}
+		})
+	}
+
+	return r.capabilities
+}
+
+// DetectCapabilities guesses what the renderer's terminal supports from the
+// process's environment variables, the way NewRendererFromEnv does, and sets
+// them on the renderer. It returns the capabilities it set.
+//
+// This function is thread-safe.
+func (r *Renderer) DetectCapabilities() Capabilities {
+	c := envCapabilities(osEnviron{})
+	r.SetCapabilities(c)
+	return c
+}
+
+// DetectCapabilities guesses what the default renderer's terminal supports
+// from the process's environment variables. See
+// [Renderer.DetectCapabilities].
+//
+// This function is thread-safe.
+func DetectCapabilities() Capabilities {
+	return DefaultRenderer().DetectCapabilities()
+}
+
+// SetCapabilities sets what the renderer's terminal supports, overriding
+// detection. To override a single capability, modify the value returned by
+// Capabilities:
+//
+//	caps := r.Capabilities()
+//	caps.Hyperlinks = true
+//	r.SetCapabilities(caps)
+//
+// This function is thread-safe.
+func (r *Renderer) SetCapabilities(c Capabilities) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.capabilities = c
+	r.explicitCapabilities = true
+}
+
+// SetCapabilities sets what the default renderer's terminal supports.
+//
+// This function is thread-safe.
+func SetCapabilities(c Capabilities) {
+	DefaultRenderer().SetCapabilities(c)
+}
+
+// defaultCapabilities are the capabilities of terminals that can't be
+// identified.
+var defaultCapabilities = Capabilities{
+	Italic:            true,
+	Strikethrough:     true,
+	UnicodeBoxDrawing: true,
+}
+
+// envCapabilities guesses the terminal's capabilities from its environment.
+func envCapabilities(env termenv.Environ) Capabilities {
+	var (
+		term        = env.Getenv("TERM")
+		termProgram = env.Getenv("TERM_PROGRAM")
+		vte, _      = strconv.Atoi(env.Getenv("VTE_VERSION"))
+	)
+
+	c := defaultCapabilities
+
+	switch {
+	case term == "linux":
+		// The Linux console shows italic text in a different color.
+		c.Italic = false
+		c.Strikethrough = false
+	case isDECTerm(term), term == "ansi":
+		c.Italic = false
+		c.Strikethrough = false
+		c.UnicodeBoxDrawing = false
+	case strings.HasPrefix(term, "screen") && termProgram != "tmux":
+		c.Italic = false
+	}
+
+	// Box drawing characters need a UTF-8 locale. LC_ALL overrides LC_CTYPE,
+	// which overrides LANG.
+	for _, k := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := env.Getenv(k); v != "" {
+			v = strings.ToLower(v)
+			if !strings.Contains(v, "utf-8") && !strings.Contains(v, "utf8") {
+				c.UnicodeBoxDrawing = false
+			}
+			break
+		}
+	}
+
+	modern := func(names ...string) bool {
+		for _, n := range names {
+			if term == n || termProgram == n {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Terminals known to support the more recent features.
+	switch {
+	case modern("xterm-ghostty", "ghostty", "wezterm", "WezTerm", "contour", "rio"):
+		c.Hyperlinks = true
+		c.StyledUnderlines = true
+		c.SynchronizedOutput = true
+		c.GraphemeWidth = true
+	case modern("xterm-kitty", "foot", "alacritty", "iTerm.app"):
+		c.Hyperlinks = true
+		c.StyledUnderlines = true
+		c.SynchronizedOutput = true
+	case env.Getenv("WT_SESSION") != "":
+		// Windows Terminal
+		c.Hyperlinks = true
+		c.SynchronizedOutput = true
+	case vte >= 5000: //nolint:mnd
+		c.Hyperlinks = true
+		c.StyledUnderlines = vte >= 5102 //nolint:mnd
+	case modern("vscode"), env.Getenv("KONSOLE_VERSION") != "":
+		c.Hyperlinks = true
+	}
+
+	return c
+}
+
+// isDECTerm reports whether TERM names a DEC video terminal, such as vt100.
+func isDECTerm(term string) bool {
+	return len(term) > 2 && strings.HasPrefix(term, "vt") && term[2] >= '0' && term[2] <= '9'
+}
+
+// asciiFallback replaces the parts of the border that aren't ASCII with their
+// ASCIIBorder counterparts.
+func (b Border) asciiFallback() Border {
+	fallback := func(part *string, ascii string) {
+		for _, c := range *part {
+			if c > 0x7f { //nolint:mnd
+				*part = ascii
+				return
+			}
+		}
+	}
+	fallback(&b.Top, asciiBorder.Top)
+	fallback(&b.Bottom, asciiBorder.Bottom)
+	fallback(&b.Left, asciiBorder.Left)
+	fallback(&b.Right, asciiBorder.Right)
+	fallback(&b.TopLeft, asciiBorder.TopLeft)
+	fallback(&b.TopRight, asciiBorder.TopRight)
+	fallback(&b.BottomLeft, asciiBorder.BottomLeft)
+	fallback(&b.BottomRight, asciiBorder.BottomRight)
+	fallback(&b.MiddleLeft, asciiBorder.MiddleLeft)
+	fallback(&b.MiddleRight, asciiBorder.MiddleRight)
+	fallback(&b.Middle, asciiBorder.Middle)
+	fallback(&b.MiddleTop, asciiBorder.MiddleTop)
+	fallback(&b.MiddleBottom, asciiBorder.MiddleBottom)
+	return b
+}
@@ -0,0 +1,137 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestEnvCapabilities(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected Capabilities
+	}{
+		{
+			name:     "unknown",
+			env:      map[string]string{},
+			expected: Capabilities{Italic: true, Strikethrough: true, UnicodeBoxDrawing: true},
+		},
+		{
+			name:     "linux console",
+			env:      map[string]string{"TERM": "linux"},
+			expected: Capabilities{UnicodeBoxDrawing: true},
+		},
+		{
+			name:     "vt100",
+			env:      map[string]string{"TERM": "vt100"},
+			expected: Capabilities{},
+		},
+		{
+			name:     "screen",
+			env:      map[string]string{"TERM": "screen-256color"},
+			expected: Capabilities{Strikethrough: true, UnicodeBoxDrawing: true},
+		},
+		{
+			name:     "tmux",
+			env:      map[string]string{"TERM": "screen-256color", "TERM_PROGRAM": "tmux"},
+			expected: Capabilities{Italic: true, Strikethrough: true, UnicodeBoxDrawing: true},
+		},
+		{
+			name:     "non UTF-8 locale",
+			env:      map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8", "LC_ALL": "C"},
+			expected: Capabilities{Italic: true, Strikethrough: true},
+		},
+		{
+			name: "ghostty",
+			env:  map[string]string{"TERM": "xterm-ghostty", "LANG": "en_US.UTF-8"},
+			expected: Capabilities{
+				Italic: true, Strikethrough: true, Hyperlinks: true, StyledUnderlines: true,
+				SynchronizedOutput: true, GraphemeWidth: true, UnicodeBoxDrawing: true,
+			},
+		},
+		{
+			name: "kitty",
+			env:  map[string]string{"TERM": "xterm-kitty"},
+			expected: Capabilities{
+				Italic: true, Strikethrough: true, Hyperlinks: true, StyledUnderlines: true,
+				SynchronizedOutput: true, UnicodeBoxDrawing: true,
+			},
+		},
+		{
+			name: "vte",
+			env:  map[string]string{"TERM": "xterm-256color", "VTE_VERSION": "6003"},
+			expected: Capabilities{
+				Italic: true, Strikethrough: true, Hyperlinks: true, StyledUnderlines: true,
+				UnicodeBoxDrawing: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if c := NewRendererFromEnv(nil, tc.env).Capabilities(); c != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, c)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesRender(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.ANSI)
+	r.SetCapabilities(Capabilities{})
+
+	t.Run("attributes", func(t *testing.T) {
+		res := r.NewStyle().Bold(true).Italic(true).Strikethrough(true).Render("hi")
+		if expected := "\x1b[1mhi\x1b[0m"; res != expected {
+			t.Errorf("expected %q, got %q", expected, res)
+		}
+	})
+
+	t.Run("ranges", func(t *testing.T) {
+		res := StyleRanges("hi", NewRange(0, 2, r.NewStyle().Italic(true).Underline(true)))
+		if expected := "\x1b[4mhi\x1b[0m"; res != expected {
+			t.Errorf("expected %q, got %q", expected, res)
+		}
+	})
+
+	t.Run("borders", func(t *testing.T) {
+		style := r.NewStyle().Border(RoundedBorder())
+		expected := "+--+\n|hi|\n+--+"
+		if res := style.Render("hi"); res != expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", expected, res)
+		}
+		if size := style.GetHorizontalFrameSize(); size != 2 {
+			t.Errorf("expected frame size 2, got %d", size)
+		}
+	})
+
+	t.Run("override", func(t *testing.T) {
+		r := NewRendererFromEnv(nil, map[string]string{"TERM": "vt100"})
+		caps := r.Capabilities()
+		caps.UnicodeBoxDrawing = true
+		r.SetCapabilities(caps)
+		if res := r.NewStyle().Border(RoundedBorder()).Render("hi"); res != "╭──╮\n│hi│\n╰──╯" {
+			t.Errorf("unexpected output:\n%s", res)
+		}
+	})
+}
+
+func TestCapabilitiesNotDetectedByDefault(t *testing.T) {
+	t.Setenv("TERM", "vt100")
+	t.Setenv("LANG", "C")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+
+	r := NewRenderer(nil)
+	if c := r.Capabilities(); c != defaultCapabilities {
+		t.Errorf("expected default capabilities, got %+v", c)
+	}
+	if c := r.DetectCapabilities(); c.UnicodeBoxDrawing || c.Italic {
+		t.Errorf("expected detected capabilities for vt100, got %+v", c)
+	}
+	if c := r.Capabilities(); c.UnicodeBoxDrawing {
+		t.Errorf("expected detected capabilities to be set, got %+v", c)
+	}
+}
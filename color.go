@@ -45,7 +45,10 @@ func (n NoColor) RGBA() (r, g, b, a uint32) {
 type Color string
 
 func (c Color) color(r *Renderer) termenv.Color {
-	return r.ColorProfile().Color(string(c))
+	if _, _, ok := splitAlpha(c); ok {
+		return r.composite(c, noColor).color(r)
+	}
+	return r.convertColor(string(c))
 }
 
 // RGBA returns the RGBA value of this color. This satisfies the Go Color
@@ -55,7 +58,7 @@ func (c Color) color(r *Renderer) termenv.Color {
 //
 // Deprecated.
 func (c Color) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+	return termenv.ConvertToRGB(c.color(DefaultRenderer())).RGBA()
 }
 
 // ANSIColor is a color specified by an ANSI color value. It's merely syntactic
@@ -78,8 +81,15 @@ func (ac ANSIColor) color(r *Renderer) termenv.Color {
 //
 // Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
 //
+// If the terminal's colors have been queried on the default renderer, the
+// actual palette color is returned.
+//
 // Deprecated.
 func (ac ANSIColor) RGBA() (r, g, b, a uint32) {
+	if ac < 256 { //nolint:mnd
+		// Use the terminal's actual palette, if known.
+		return DefaultRenderer().paletteColor(int(ac)).RGBA()
+	}
 	cf := Color(strconv.FormatUint(uint64(ac), 10))
 	return cf.RGBA()
 }
@@ -110,7 +120,7 @@ func (ac AdaptiveColor) color(r *Renderer) termenv.Color {
 //
 // Deprecated.
 func (ac AdaptiveColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(ac.color(renderer)).RGBA()
+	return termenv.ConvertToRGB(ac.color(DefaultRenderer())).RGBA()
 }
 
 // CompleteColor specifies exact values for truecolor, ANSI256, and ANSI color
@@ -125,11 +135,11 @@ func (c CompleteColor) color(r *Renderer) termenv.Color {
 	p := r.ColorProfile()
 	switch p { //nolint:exhaustive
 	case termenv.TrueColor:
-		return p.Color(c.TrueColor)
+		return p.Color(r.simulateColorBlindness(c.TrueColor))
 	case termenv.ANSI256:
-		return p.Color(c.ANSI256)
+		return p.Color(r.simulateColorBlindness(c.ANSI256))
 	case termenv.ANSI:
-		return p.Color(c.ANSI)
+		return p.Color(r.simulateColorBlindness(c.ANSI))
 	default:
 		return termenv.NoColor{}
 	}
@@ -143,7 +153,7 @@ func (c CompleteColor) color(r *Renderer) termenv.Color {
 //
 // Deprecated.
 func (c CompleteColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(c.color(renderer)).RGBA()
+	return termenv.ConvertToRGB(c.color(DefaultRenderer())).RGBA()
 }
 
 // CompleteAdaptiveColor specifies exact values for truecolor, ANSI256, and ANSI color
@@ -168,5 +178,5 @@ func (cac CompleteAdaptiveColor) color(r *Renderer) termenv.Color {
 //
 // Deprecated.
 func (cac CompleteAdaptiveColor) RGBA() (r, g, b, a uint32) {
-	return termenv.ConvertToRGB(cac.color(renderer)).RGBA()
+	return termenv.ConvertToRGB(cac.color(DefaultRenderer())).RGBA()
 }
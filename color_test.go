@@ -8,7 +8,7 @@ import (
 )
 
 func TestSetColorProfile(t *testing.T) {
-	r := renderer
+	r := DefaultRenderer()
 	input := "hello"
 
 	tt := []struct {
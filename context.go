@@ -0,0 +1,50 @@
+package lipgloss
+
+import (
+	"context"
+)
+
+// rendererKey is the context key for the renderer carried by a context.
+type rendererKey struct{}
+
+// WithRenderer returns a copy of ctx that carries the given renderer. Styles
+// rendered with Style.RenderContext and that context use it, unless they were
+// created with a renderer of their own. This makes it possible to render
+// shared styles, created with NewStyle, differently for each client of a
+// server:
+//
+//	ctx = lipgloss.WithRenderer(ctx, lipgloss.NewRenderer(session))
+//	title.RenderContext(ctx, "Hello")
+//
+// Go has no goroutine-local state, so the context has to be passed all the
+// way down, to every call that renders: only Style.RenderContext and styles
+// created with NewStyleContext use the renderer it carries. Anything rendered
+// with Style.Render along the way, including by other packages, uses the
+// default renderer instead.
+func WithRenderer(ctx context.Context, r *Renderer) context.Context {
+	return context.WithValue(ctx, rendererKey{}, r)
+}
+
+// RendererFromContext returns the renderer carried by ctx, or the default
+// renderer if it carries none.
+func RendererFromContext(ctx context.Context) *Renderer {
+	if r, ok := ctx.Value(rendererKey{}).(*Renderer); ok && r != nil {
+		return r
+	}
+	return DefaultRenderer()
+}
+
+// NewStyleContext returns a new, empty Style that renders with the renderer
+// carried by ctx. See WithRenderer.
+func NewStyleContext(ctx context.Context) Style {
+	return RendererFromContext(ctx).NewStyle()
+}
+
+// RenderContext is like Render, but renders with the renderer carried by ctx
+// if the style wasn't created with a renderer of its own. See WithRenderer.
+func (s Style) RenderContext(ctx context.Context, strs ...string) string {
+	if s.r == nil {
+		s.r = RendererFromContext(ctx)
+	}
+	return s.Render(strs...)
+}
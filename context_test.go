@@ -0,0 +1,61 @@
+package lipgloss
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestRenderContext(t *testing.T) {
+	ansi := NewRenderer(io.Discard)
+	ansi.SetColorProfile(termenv.ANSI)
+	ascii := NewRenderer(io.Discard)
+	ascii.SetColorProfile(termenv.Ascii)
+
+	style := NewStyle().Foreground(Color("1"))
+
+	if res := style.RenderContext(WithRenderer(context.Background(), ansi), "hi"); res != "\x1b[31mhi\x1b[0m" {
+		t.Errorf("expected the context's renderer to be used, got %q", res)
+	}
+	if res := style.RenderContext(WithRenderer(context.Background(), ascii), "hi"); res != "hi" {
+		t.Errorf("expected the context's renderer to be used, got %q", res)
+	}
+	if res := NewStyleContext(WithRenderer(context.Background(), ansi)).Bold(true).Render("hi"); res != "\x1b[1mhi\x1b[0m" {
+		t.Errorf("expected the context's renderer to be used, got %q", res)
+	}
+
+	// Styles with a renderer of their own keep it.
+	own := ansi.NewStyle().Foreground(Color("1"))
+	if res := own.RenderContext(WithRenderer(context.Background(), ascii), "hi"); res != "\x1b[31mhi\x1b[0m" {
+		t.Errorf("expected the style's renderer to be used, got %q", res)
+	}
+
+	if r := RendererFromContext(context.Background()); r != DefaultRenderer() {
+		t.Error("expected the default renderer for a context without one")
+	}
+}
+
+func TestSetDefaultRendererConcurrently(t *testing.T) {
+	prev := DefaultRenderer()
+	defer SetDefaultRenderer(prev)
+
+	style := NewStyle().Bold(true)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r := NewRenderer(io.Discard)
+			r.SetColorProfile(termenv.ANSI)
+			SetDefaultRenderer(r)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = style.Render("hi")
+		}()
+	}
+	wg.Wait()
+}
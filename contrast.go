@@ -0,0 +1,69 @@
+package lipgloss
+
+import (
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two colors, from 1
+// (no contrast) to 21 (black on white). WCAG recommends a ratio of at least
+// 4.5 for regular text and 3 for large text.
+//
+// Adaptive colors are resolved against the renderer's background, and
+// NoColor stands for the background itself. Unless the terminal's colors have
+// been queried, the background is taken to be black on dark backgrounds and
+// white on light ones.
+func (r *Renderer) ContrastRatio(a, b TerminalColor) float64 {
+	la := luminance(r.resolveOrBackground(a))
+	lb := luminance(r.resolveOrBackground(b))
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05) //nolint:mnd
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two colors,
+// resolving adaptive colors against the default renderer. See
+// [Renderer.ContrastRatio].
+func ContrastRatio(a, b TerminalColor) float64 {
+	return DefaultRenderer().ContrastRatio(a, b)
+}
+
+// readableForeground returns the color that contrasts the most with the
+// background. Black and white are considered, unless the renderer is limited
+// to the 16 ANSI colors, in which case every entry of the palette is.
+func (r *Renderer) readableForeground(bg TerminalColor) TerminalColor {
+	candidates := []TerminalColor{Color("#000000"), Color("#ffffff")}
+	if r.ColorProfile() == termenv.ANSI {
+		candidates = candidates[:0]
+		for i := ANSIColor(0); i < 16; i++ {
+			candidates = append(candidates, i)
+		}
+	}
+
+	var (
+		best      = candidates[0]
+		bestRatio float64
+	)
+	for _, c := range candidates {
+		if ratio := r.ContrastRatio(c, bg); ratio > bestRatio {
+			best, bestRatio = c, ratio
+		}
+	}
+	return best
+}
+
+// resolveOrBackground is like resolveColor, but colors that can't be resolved
+// are taken to be the terminal's background.
+func (r *Renderer) resolveOrBackground(c TerminalColor) colorful.Color {
+	if cf, ok := r.resolveColor(c); ok {
+		return cf
+	}
+	return r.backgroundColor()
+}
+
+// luminance returns the relative luminance of a color as defined by WCAG 2.
+func luminance(c colorful.Color) float64 {
+	r, g, b := c.LinearRgb()
+	return 0.2126*r + 0.7152*g + 0.0722*b //nolint:mnd
+}
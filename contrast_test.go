@@ -0,0 +1,109 @@
+package lipgloss
+
+import (
+	"math"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestContrastRatio(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetHasDarkBackground(true)
+
+	tests := []struct {
+		name     string
+		a, b     TerminalColor
+		expected float64
+	}{
+		{"black on white", Color("#000000"), Color("#ffffff"), 21},
+		{"order doesn't matter", Color("#ffffff"), Color("#000000"), 21},
+		{"same color", Color("#5a56e0"), Color("#5a56e0"), 1},
+		{"grey on white", Color("#767676"), Color("#ffffff"), 4.54},
+		{"ansi", ANSIColor(15), ANSIColor(0), 21},
+		{"background", Color("#ffffff"), NoColor{}, 21},
+		{"adaptive", AdaptiveColor{Light: "#ffffff", Dark: "#000000"}, NoColor{}, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := r.ContrastRatio(tc.a, tc.b)
+			if math.Abs(res-tc.expected) > 0.01 {
+				t.Errorf("expected %.2f, got %.2f", tc.expected, res)
+			}
+		})
+	}
+
+	t.Run("light background", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetHasDarkBackground(false)
+		if res := r.ContrastRatio(Color("#000000"), NoColor{}); math.Abs(res-21) > 0.01 {
+			t.Errorf("expected 21, got %.2f", res)
+		}
+	})
+}
+
+func TestAutoForeground(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  termenv.Profile
+		dark     bool
+		style    func(Style) Style
+		expected string
+	}{
+		{
+			name:     "dark background color",
+			profile:  termenv.TrueColor,
+			style:    func(s Style) Style { return s.Background(Color("#1e1e8c")) },
+			expected: "\x1b[38;2;255;255;255;48;2;30;30;140mhi\x1b[0m",
+		},
+		{
+			name:     "light background color",
+			profile:  termenv.TrueColor,
+			style:    func(s Style) Style { return s.Background(Color("#ffd700")) },
+			expected: "\x1b[38;2;0;0;0;48;2;255;215;0mhi\x1b[0m",
+		},
+		{
+			name:     "terminal background",
+			profile:  termenv.TrueColor,
+			dark:     true,
+			style:    func(s Style) Style { return s },
+			expected: "\x1b[38;2;255;255;255mhi\x1b[0m",
+		},
+		{
+			name:     "overrides foreground",
+			profile:  termenv.TrueColor,
+			style:    func(s Style) Style { return s.Foreground(Color("#ffff00")).Background(Color("#ffff00")) },
+			expected: "\x1b[38;2;0;0;0;48;2;255;255;0mhi\x1b[0m",
+		},
+		{
+			name:     "ansi palette",
+			profile:  termenv.ANSI,
+			style:    func(s Style) Style { return s.Background(ANSIColor(4)) },
+			expected: "\x1b[97;44mhi\x1b[0m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(nil)
+			r.SetColorProfile(tc.profile)
+			r.SetHasDarkBackground(tc.dark)
+			s := tc.style(r.NewStyle().AutoForeground(true))
+			if res := s.Render("hi"); res != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+
+	s := NewStyle().AutoForeground(true)
+	if !s.GetAutoForeground() {
+		t.Error("expected auto foreground to be set")
+	}
+	if s.UnsetAutoForeground().GetAutoForeground() {
+		t.Error("expected auto foreground to be unset")
+	}
+	if !NewStyle().Inherit(s).GetAutoForeground() {
+		t.Error("expected auto foreground to be inherited")
+	}
+}
@@ -0,0 +1,109 @@
+package lipgloss
+
+import (
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ColorBlindness is a kind of color vision deficiency that a renderer can
+// simulate.
+type ColorBlindness int
+
+// Available color vision deficiencies.
+const (
+	// NoColorBlindness renders colors as they are. This is the default.
+	NoColorBlindness ColorBlindness = iota
+
+	// Protanopia is the absence of red-sensitive cones.
+	Protanopia
+
+	// Deuteranopia is the absence of green-sensitive cones.
+	Deuteranopia
+
+	// Tritanopia is the absence of blue-sensitive cones.
+	Tritanopia
+
+	// Achromatopsia is the absence of color vision altogether.
+	Achromatopsia
+)
+
+// Simulation matrices for linear RGB, from Machado, Oliveira and Fernandes,
+// "A Physiologically-based Model for Simulation of Color Vision Deficiency"
+// (2009), at full severity.
+var colorBlindnessMatrices = map[ColorBlindness][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// ColorBlindness returns the color vision deficiency the renderer simulates.
+func (r *Renderer) ColorBlindness() ColorBlindness {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.colorBlindness
+}
+
+// SimulateColorBlindness makes the renderer transform every color it renders
+// to how it looks to people with the given color vision deficiency. This is
+// useful to preview how accessible an interface is, or to test that colors
+// stay distinguishable. Use NoColorBlindness to turn the simulation off.
+//
+// This function is thread-safe.
+func (r *Renderer) SimulateColorBlindness(cb ColorBlindness) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.colorBlindness = cb
+	r.downsampleCache.Clear()
+}
+
+// SimulateColorBlindness makes the default renderer simulate the given color
+// vision deficiency.
+//
+// This function is thread-safe.
+func SimulateColorBlindness(cb ColorBlindness) {
+	DefaultRenderer().SimulateColorBlindness(cb)
+}
+
+// simulateColorBlindness returns the hex or ANSI color value as seen with the
+// renderer's simulated color vision deficiency. Values that can't be parsed
+// are returned as they are.
+func (r *Renderer) simulateColorBlindness(s string) string {
+	cb := r.ColorBlindness()
+	if cb == NoColorBlindness {
+		return s
+	}
+	c, ok := r.parseHexOrANSI(s)
+	if !ok {
+		return s
+	}
+	return simulateColorBlindness(c, cb).Hex()
+}
+
+func simulateColorBlindness(c colorful.Color, cb ColorBlindness) colorful.Color {
+	r, g, b := c.LinearRgb()
+	if cb == Achromatopsia {
+		y := 0.2126*r + 0.7152*g + 0.0722*b //nolint:mnd
+		return colorful.LinearRgb(y, y, y).Clamped()
+	}
+
+	m, ok := colorBlindnessMatrices[cb]
+	if !ok {
+		return c
+	}
+	return colorful.LinearRgb(
+		m[0][0]*r+m[0][1]*g+m[0][2]*b,
+		m[1][0]*r+m[1][1]*g+m[1][2]*b,
+		m[2][0]*r+m[2][1]*g+m[2][2]*b,
+	).Clamped()
+}
@@ -0,0 +1,47 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestSimulateColorBlindness(t *testing.T) {
+	tests := []struct {
+		name     string
+		cb       ColorBlindness
+		color    TerminalColor
+		expected string
+	}{
+		{"none", NoColorBlindness, Color("#ff0000"), "\x1b[38;2;255;0;0mhi\x1b[0m"},
+		{"protanopia", Protanopia, Color("#ff0000"), "\x1b[38;2;109;95;0mhi\x1b[0m"},
+		{"deuteranopia", Deuteranopia, Color("#ff0000"), "\x1b[38;2;163;144;0mhi\x1b[0m"},
+		{"tritanopia", Tritanopia, Color("#ff0000"), "\x1b[38;2;255;0;15mhi\x1b[0m"},
+		{"achromatopsia", Achromatopsia, Color("#ff0000"), "\x1b[38;2;127;127;127mhi\x1b[0m"},
+		{"ansi", Achromatopsia, ANSIColor(9), "\x1b[38;2;127;127;127mhi\x1b[0m"},
+		{"complete", Achromatopsia, CompleteColor{TrueColor: "#ff0000"}, "\x1b[38;2;127;127;127mhi\x1b[0m"},
+		{"grey is unchanged", Protanopia, Color("#808080"), "\x1b[38;2;128;128;128mhi\x1b[0m"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(nil)
+			r.SetColorProfile(termenv.TrueColor)
+			r.SimulateColorBlindness(tc.cb)
+			if res := r.NewStyle().Foreground(tc.color).Render("hi"); res != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+
+	t.Run("red and green are indistinguishable", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.ANSI)
+		r.SimulateColorBlindness(Deuteranopia)
+		red := r.NewStyle().Foreground(Color("#d70000")).Render("x")
+		green := r.NewStyle().Foreground(Color("#5f8700")).Render("x")
+		if red != green {
+			t.Errorf("expected %q and %q to render the same", red, green)
+		}
+	})
+}
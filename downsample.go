@@ -0,0 +1,182 @@
+package lipgloss
+
+import (
+	"image/color"
+
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/muesli/termenv"
+)
+
+// Downsampling determines how colors are converted when the renderer's color
+// profile doesn't support them, such as when a hex color is rendered with the
+// ANSI256 or ANSI profile.
+type Downsampling int
+
+// Available downsampling methods.
+const (
+	// NearestDownsampling picks the nearest color the way termenv does. This
+	// is the default.
+	NearestDownsampling Downsampling = iota
+
+	// PerceptualDownsampling picks the color that looks the most similar,
+	// as measured by the CIEDE2000 color difference. It's slower than
+	// NearestDownsampling, but less prone to surprises such as greys turning
+	// blue. It takes the terminal's palette into account, if set.
+	PerceptualDownsampling
+)
+
+// Downsampling returns the method used to convert colors on the renderer.
+func (r *Renderer) Downsampling() Downsampling {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.downsampling
+}
+
+// SetDownsampling sets the method used to convert colors that the renderer's
+// color profile doesn't support.
+//
+// This function is thread-safe.
+func (r *Renderer) SetDownsampling(d Downsampling) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.downsampling = d
+	r.downsampleCache.Clear()
+}
+
+// SetDownsampling sets the method used to convert colors on the default
+// renderer.
+//
+// This function is thread-safe.
+func SetDownsampling(d Downsampling) {
+	DefaultRenderer().SetDownsampling(d)
+}
+
+// Palette returns the terminal palette set on the renderer.
+func (r *Renderer) Palette() []color.Color {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return append([]color.Color(nil), r.palette...)
+}
+
+// SetPalette sets the colors the terminal actually uses for its palette,
+// starting at index 0. Entries that are missing or nil keep their xterm
+// default. PerceptualDownsampling uses the palette to pick colors; in
+// particular, the first 16 entries are only picked for the ANSI256 profile
+// when they're known, since terminal themes commonly change them.
+//
+// This function is thread-safe.
+func (r *Renderer) SetPalette(p []color.Color) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.palette = append([]color.Color(nil), p...)
+	r.downsampleCache.Clear()
+}
+
+// SetPalette sets the colors the terminal actually uses for its palette on
+// the default renderer.
+//
+// This function is thread-safe.
+func SetPalette(p []color.Color) {
+	DefaultRenderer().SetPalette(p)
+}
+
+type downsampleKey struct {
+	profile termenv.Profile
+	color   string
+}
+
+// convertColor converts a hex or ANSI color value to the renderer's color
+// profile. It returns nil if the value is invalid.
+func (r *Renderer) convertColor(s string) termenv.Color {
+	s = r.simulateColorBlindness(s)
+	p := r.ColorProfile()
+	if r.Downsampling() == NearestDownsampling || (p != termenv.ANSI && p != termenv.ANSI256) {
+		return p.Color(s)
+	}
+
+	key := downsampleKey{p, s}
+	if c, ok := r.downsampleCache.Load(key); ok {
+		return c.(termenv.Color) //nolint:forcetypeassert
+	}
+
+	var c termenv.Color
+	switch v := termenv.TrueColor.Color(s).(type) {
+	case termenv.ANSIColor:
+		c = v
+	case termenv.ANSI256Color:
+		if p == termenv.ANSI256 || v > 255 {
+			c = p.Convert(v)
+		} else {
+			c = r.nearestColor(r.paletteColor(int(v)), p)
+		}
+	case termenv.RGBColor:
+		if h, err := colorful.Hex(string(v)); err == nil {
+			c = r.nearestColor(h, p)
+		}
+	}
+
+	// Invalid colors aren't cached: a nil interface can't be stored as a
+	// termenv.Color.
+	if c != nil {
+		r.downsampleCache.Store(key, c)
+	}
+	return c
+}
+
+// nearestColor returns the palette entry that looks the most similar to c.
+// Ties go to the lowest index, so the result is deterministic.
+func (r *Renderer) nearestColor(c colorful.Color, p termenv.Profile) termenv.Color {
+	from, to := 0, 16 //nolint:mnd
+	if p == termenv.ANSI256 {
+		from, to = 16, 256 //nolint:mnd
+		if r.hasPaletteBase() {
+			from = 0
+		}
+	}
+
+	best, bestDist := from, -1.0
+	for i := from; i < to; i++ {
+		if d := c.DistanceCIEDE2000(r.paletteColor(i)); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+
+	if best < 16 { //nolint:mnd
+		return termenv.ANSIColor(best)
+	}
+	return termenv.ANSI256Color(best)
+}
+
+// paletteColor returns the color of a palette entry, falling back to the
+// xterm default.
+func (r *Renderer) paletteColor(i int) colorful.Color {
+	r.mtx.RLock()
+	var pc color.Color
+	if i < len(r.palette) {
+		pc = r.palette[i]
+	}
+	r.mtx.RUnlock()
+
+	if pc != nil {
+		if c, ok := colorful.MakeColor(pc); ok {
+			return c
+		}
+	}
+	c, _ := colorful.Hex(termenv.ANSI256Color(i).String())
+	return c
+}
+
+// hasPaletteBase reports whether the first 16 palette entries are known.
+func (r *Renderer) hasPaletteBase() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	if len(r.palette) < 16 { //nolint:mnd
+		return false
+	}
+	for _, c := range r.palette[:16] {
+		if c == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package lipgloss
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestDownsampling(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  termenv.Profile
+		method   Downsampling
+		palette  []color.Color
+		color    TerminalColor
+		expected string
+	}{
+		{"nearest grey", termenv.ANSI256, NearestDownsampling, nil, Color("#808080"), "38;5;102"},
+		{"perceptual grey", termenv.ANSI256, PerceptualDownsampling, nil, Color("#808080"), "38;5;244"},
+		{"perceptual exact", termenv.ANSI256, PerceptualDownsampling, nil, Color("#ff8700"), "38;5;208"},
+		{"perceptual ansi", termenv.ANSI, PerceptualDownsampling, nil, Color("#3a3a3a"), "30"},
+		{"perceptual ansi256 to ansi", termenv.ANSI, PerceptualDownsampling, nil, Color("196"), "91"},
+		{"ansi is kept", termenv.ANSI256, PerceptualDownsampling, nil, Color("4"), "34"},
+		{"ansi256 is kept", termenv.ANSI256, PerceptualDownsampling, nil, ANSIColor(100), "38;5;100"},
+		{"truecolor is unaffected", termenv.TrueColor, PerceptualDownsampling, nil, Color("#808080"), "38;2;128;128;128"},
+		{"invalid", termenv.ANSI256, PerceptualDownsampling, nil, Color("#zzz"), ""},
+		{
+			name:    "palette",
+			profile: termenv.ANSI,
+			method:  PerceptualDownsampling,
+			palette: []color.Color{
+				color.RGBA{0x28, 0x2a, 0x36, 0xff}, // a theme's "black"
+				color.RGBA{0xff, 0x55, 0x55, 0xff},
+			},
+			color:    Color("#2a2a38"),
+			expected: "30",
+		},
+		{
+			name:    "palette base in ansi256",
+			profile: termenv.ANSI256,
+			method:  PerceptualDownsampling,
+			palette: []color.Color{
+				color.Black, color.Black, color.Black, color.Black,
+				color.Black, color.Black, color.Black, color.Black,
+				color.Black, color.Black, color.Black, color.Black,
+				color.Black, color.Black, color.Black,
+				color.RGBA{0x12, 0x34, 0x56, 0xff},
+			},
+			color:    Color("#123456"),
+			expected: "97",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(nil)
+			r.SetColorProfile(tc.profile)
+			r.SetDownsampling(tc.method)
+			r.SetPalette(tc.palette)
+
+			c := tc.color.color(r)
+			var res string
+			if c != nil {
+				res = c.Sequence(false)
+			}
+			if res != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestDownsamplingIsDeterministic(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.ANSI256)
+	r.SetDownsampling(PerceptualDownsampling)
+
+	style := r.NewStyle().Foreground(Color("#5a56e0"))
+	expected := style.Render("hello")
+	for range 10 {
+		r.SetDownsampling(PerceptualDownsampling) // clears the cache
+		if res := style.Render("hello"); res != expected {
+			t.Fatalf("expected %q, got %q", expected, res)
+		}
+	}
+}
+
+func TestDownsamplingInvalidColor(t *testing.T) {
+	for _, p := range []termenv.Profile{termenv.ANSI, termenv.ANSI256} {
+		r := NewRenderer(nil)
+		r.SetColorProfile(p)
+		r.SetDownsampling(PerceptualDownsampling)
+
+		style := r.NewStyle().Foreground(Color("nope"))
+		for range 2 {
+			if res := style.Render("hello"); res != "hello" {
+				t.Errorf("expected invalid color to be ignored, got %q", res)
+			}
+		}
+	}
+}
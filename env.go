@@ -0,0 +1,134 @@
+package lipgloss
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// EnvOption is a hint about the terminal for NewRendererFromEnv, for things
+// that can't be told from environment variables.
+type EnvOption func(*envOptions)
+
+type envOptions struct {
+	profile *termenv.Profile
+	dark    *bool
+	caps    *Capabilities
+}
+
+// WithEnvColorProfile sets the color profile of a renderer created with
+// NewRendererFromEnv, instead of detecting it from the environment.
+func WithEnvColorProfile(p termenv.Profile) EnvOption {
+	return func(o *envOptions) {
+		o.profile = &p
+	}
+}
+
+// WithEnvDarkBackground sets whether the terminal of a renderer created with
+// NewRendererFromEnv has a dark background, instead of guessing it from the
+// environment.
+func WithEnvDarkBackground(v bool) EnvOption {
+	return func(o *envOptions) {
+		o.dark = &v
+	}
+}
+
+// WithEnvCapabilities sets the capabilities of a renderer created with
+// NewRendererFromEnv, instead of detecting them from the environment.
+func WithEnvCapabilities(c Capabilities) EnvOption {
+	return func(o *envOptions) {
+		o.caps = &c
+	}
+}
+
+// NewRendererFromEnv creates a new Renderer for a terminal described by its
+// environment variables, such as TERM, COLORTERM, NO_COLOR and COLORFGBG,
+// rather than by inspecting w. This is useful when the terminal isn't local,
+// like in SSH and web terminal servers, which know the client's environment
+// from the session. The terminal is assumed to be a TTY, and it's never
+// queried.
+//
+// Unless hinted otherwise, the color profile and capabilities are detected
+// from the environment, and the background is dark unless COLORFGBG says
+// otherwise.
+//
+// Example usage:
+//
+//	env := map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}
+//	r := lipgloss.NewRendererFromEnv(sess, env, lipgloss.WithEnvDarkBackground(false))
+func NewRendererFromEnv(w io.Writer, env map[string]string, opts ...EnvOption) *Renderer {
+	var o envOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	environ := mapEnviron(env)
+	output := termenv.NewOutput(w, termenv.WithEnvironment(environ), termenv.WithTTY(true))
+	r := &Renderer{output: output, environ: environ}
+
+	if o.profile != nil {
+		r.SetColorProfile(*o.profile)
+	} else {
+		r.SetColorProfile(output.EnvColorProfile())
+	}
+
+	if o.dark != nil {
+		r.SetHasDarkBackground(*o.dark)
+	} else {
+		r.SetHasDarkBackground(envHasDarkBackground(env))
+	}
+
+	if o.caps != nil {
+		r.SetCapabilities(*o.caps)
+	}
+
+	return r
+}
+
+// envHasDarkBackground guesses whether the background is dark from the
+// COLORFGBG variable, which some terminals set to "fg;bg" palette indices.
+// Without it, the background is taken to be dark.
+func envHasDarkBackground(env map[string]string) bool {
+	fgbg := env["COLORFGBG"]
+	i := strings.LastIndexByte(fgbg, ';')
+	if i < 0 {
+		return true
+	}
+	bg, err := strconv.Atoi(fgbg[i+1:])
+	if err != nil {
+		return true
+	}
+	// Palette entries 7 (white) and 9 to 15 (bright colors) are light.
+	return bg < 7 || bg == 8 //nolint:mnd
+}
+
+// osEnviron gives access to the process's environment variables.
+type osEnviron struct{}
+
+func (osEnviron) Environ() []string {
+	return os.Environ()
+}
+
+func (osEnviron) Getenv(key string) string {
+	return os.Getenv(key)
+}
+
+// mapEnviron makes a map of environment variables usable with termenv.
+type mapEnviron map[string]string
+
+func (e mapEnviron) Environ() []string {
+	environ := make([]string, 0, len(e))
+	for k, v := range e {
+		environ = append(environ, k+"="+v)
+	}
+	sort.Strings(environ)
+	return environ
+}
+
+func (e mapEnviron) Getenv(key string) string {
+	return e[key]
+}
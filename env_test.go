@@ -0,0 +1,44 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestNewRendererFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		opts    []EnvOption
+		profile termenv.Profile
+		dark    bool
+	}{
+		{"empty", map[string]string{}, nil, termenv.Ascii, true},
+		{"truecolor", map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, nil, termenv.TrueColor, true},
+		{"256 colors", map[string]string{"TERM": "screen-256color"}, nil, termenv.ANSI256, true},
+		{"16 colors", map[string]string{"TERM": "xterm"}, nil, termenv.ANSI, true},
+		{"no color", map[string]string{"TERM": "xterm-kitty", "NO_COLOR": "1"}, nil, termenv.Ascii, true},
+		{"light COLORFGBG", map[string]string{"TERM": "xterm", "COLORFGBG": "0;15"}, nil, termenv.ANSI, false},
+		{"dark COLORFGBG", map[string]string{"TERM": "xterm", "COLORFGBG": "15;default;0"}, nil, termenv.ANSI, true},
+		{
+			"hints",
+			map[string]string{"TERM": "xterm", "COLORFGBG": "15;0"},
+			[]EnvOption{WithEnvColorProfile(termenv.TrueColor), WithEnvDarkBackground(false)},
+			termenv.TrueColor,
+			false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRendererFromEnv(nil, tc.env, tc.opts...)
+			if p := r.ColorProfile(); p != tc.profile {
+				t.Errorf("expected profile %d, got %d", tc.profile, p)
+			}
+			if dark := r.HasDarkBackground(); dark != tc.dark {
+				t.Errorf("expected dark background %t, got %t", tc.dark, dark)
+			}
+		})
+	}
+}
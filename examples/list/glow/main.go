@@ -3,8 +3,8 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/list"
+	"github.com/rhystmorgan/lipgloss"
+	"github.com/rhystmorgan/lipgloss/list"
 )
 
 type Document struct {
@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss/list"
+	"github.com/rhystmorgan/lipgloss/list"
 )
 
 func main() {
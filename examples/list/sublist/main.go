@@ -3,10 +3,10 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/list"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/rhystmorgan/lipgloss"
+	"github.com/rhystmorgan/lipgloss/list"
+	"github.com/rhystmorgan/lipgloss/table"
 )
 
 func main() {
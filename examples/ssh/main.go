@@ -3,24 +3,21 @@ package main
 // This example demonstrates how to use a custom Lip Gloss renderer with Wish,
 // a package for building custom SSH servers.
 //
-// The big advantage to using custom renderers here is that we can accurately
-// detect the background color and color profile for each client and render
-// against that accordingly.
+// The big advantage to using custom renderers here is that we can pick the
+// color profile and background for each client from its environment, and
+// render against that accordingly.
 //
 // For details on wish see: https://github.com/charmbracelet/wish/
 
 import (
 	"fmt"
 	"log"
-	"os"
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	lm "github.com/charmbracelet/wish/logging"
-	"github.com/creack/pty"
-	"github.com/muesli/termenv"
+	"github.com/rhystmorgan/lipgloss"
 )
 
 // Available styles.
@@ -57,66 +54,22 @@ func makeStyles(r *lipgloss.Renderer) styles {
 	}
 }
 
-// Bridge Wish and Termenv so we can query for a user's terminal capabilities.
-type sshOutput struct {
-	ssh.Session
-	tty *os.File
-}
-
-func (s *sshOutput) Write(p []byte) (int, error) {
-	return s.Session.Write(p)
-}
-
-func (s *sshOutput) Read(p []byte) (int, error) {
-	return s.Session.Read(p)
-}
-
-func (s *sshOutput) Fd() uintptr {
-	return s.tty.Fd()
-}
-
-type sshEnviron struct {
-	environ []string
-}
-
-func (s *sshEnviron) Getenv(key string) string {
-	for _, v := range s.environ {
-		if strings.HasPrefix(v, key+"=") {
-			return v[len(key)+1:]
+// Describe the client's terminal from the session. The SSH client sends TERM
+// along with the pty request, and may send other variables, such as
+// COLORTERM, too.
+func envFromSession(sess ssh.Session, term string) map[string]string {
+	env := map[string]string{"TERM": term}
+	for _, kv := range sess.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
 		}
 	}
-	return ""
-}
-
-func (s *sshEnviron) Environ() []string {
-	return s.environ
-}
-
-// Create a termenv.Output from the session.
-func outputFromSession(sess ssh.Session) *termenv.Output {
-	sshPty, _, _ := sess.Pty()
-	_, tty, err := pty.Open()
-	if err != nil {
-		log.Fatal(err)
-	}
-	o := &sshOutput{
-		Session: sess,
-		tty:     tty,
-	}
-	environ := sess.Environ()
-	environ = append(environ, fmt.Sprintf("TERM=%s", sshPty.Term))
-	e := &sshEnviron{environ: environ}
-	// We need to use unsafe mode here because the ssh session is not running
-	// locally and we already know that the session is a TTY.
-	return termenv.NewOutput(o, termenv.WithUnsafe(), termenv.WithEnvironment(e))
+	return env
 }
 
 // Handle SSH requests.
 func handler(next ssh.Handler) ssh.Handler {
 	return func(sess ssh.Session) {
-		// Get client's output.
-		clientOutput := outputFromSession(sess)
-
 		pty, _, active := sess.Pty()
 		if !active {
 			next(sess)
@@ -124,9 +77,8 @@ func handler(next ssh.Handler) ssh.Handler {
 		}
 		width := pty.Window.Width
 
-		// Initialize new renderer for the client.
-		renderer := lipgloss.NewRenderer(sess)
-		renderer.SetOutput(clientOutput)
+		// Initialize new renderer for the client from its environment.
+		renderer := lipgloss.NewRendererFromEnv(sess, envFromSession(sess, pty.Term))
 
 		// Initialize new styles against the renderer.
 		styles := makeStyles(renderer)
@@ -161,9 +113,8 @@ func handler(next ssh.Handler) ssh.Handler {
 			styles.gray,
 		)
 
-		fmt.Fprintf(&str, "%s %t %s\n\n", styles.bold.UnsetString().Render("Has dark background?"),
-			renderer.HasDarkBackground(),
-			renderer.Output().BackgroundColor())
+		fmt.Fprintf(&str, "%s %t\n\n", styles.bold.UnsetString().Render("Has dark background?"),
+			renderer.HasDarkBackground())
 
 		block := renderer.Place(width,
 			lipgloss.Height(str.String()), lipgloss.Center, lipgloss.Center, str.String(),
@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss/tree"
+	"github.com/rhystmorgan/lipgloss/tree"
 )
 
 func main() {
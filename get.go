@@ -1,11 +1,5 @@
 package lipgloss
 
-import (
-	"strings"
-
-	"github.com/charmbracelet/x/ansi"
-)
-
 // GetBold returns the style's bold value. If no value is set false is returned.
 func (s Style) GetBold() bool {
 	return s.getAsBool(boldKey, false)
@@ -47,6 +41,12 @@ func (s Style) GetFaint() bool {
 	return s.getAsBool(faintKey, false)
 }
 
+// GetAutoForeground returns the style's auto foreground value. If no value is
+// set false is returned.
+func (s Style) GetAutoForeground() bool {
+	return s.getAsBool(autoForegroundKey, false)
+}
+
 // GetForeground returns the style's foreground color. If no value is set
 // NoColor{} is returned.
 func (s Style) GetForeground() TerminalColor {
@@ -303,7 +303,7 @@ func (s Style) GetBorderTopSize() int {
 	if !s.getAsBool(borderTopKey, false) && !s.implicitBorders() {
 		return 0
 	}
-	return s.getBorderStyle().GetTopSize()
+	return s.getRenderedBorderStyle().topSize(s.getRenderer())
 }
 
 // GetBorderLeftSize returns the width of the left border. If borders contain
@@ -313,7 +313,7 @@ func (s Style) GetBorderLeftSize() int {
 	if !s.getAsBool(borderLeftKey, false) && !s.implicitBorders() {
 		return 0
 	}
-	return s.getBorderStyle().GetLeftSize()
+	return s.getRenderedBorderStyle().leftSize(s.getRenderer())
 }
 
 // GetBorderBottomSize returns the width of the bottom border. If borders
@@ -323,7 +323,7 @@ func (s Style) GetBorderBottomSize() int {
 	if !s.getAsBool(borderBottomKey, false) && !s.implicitBorders() {
 		return 0
 	}
-	return s.getBorderStyle().GetBottomSize()
+	return s.getRenderedBorderStyle().bottomSize(s.getRenderer())
 }
 
 // GetBorderRightSize returns the width of the right border. If borders
@@ -333,7 +333,7 @@ func (s Style) GetBorderRightSize() int {
 	if !s.getAsBool(borderRightKey, false) && !s.implicitBorders() {
 		return 0
 	}
-	return s.getBorderStyle().GetRightSize()
+	return s.getRenderedBorderStyle().rightSize(s.getRenderer())
 }
 
 // GetHorizontalBorderSize returns the width of the horizontal borders. If
@@ -374,6 +374,12 @@ func (s Style) GetTabWidth() int {
 	return s.getAsInt(tabWidthKey)
 }
 
+// GetTabMode returns the style's tab mode setting. If no value is set
+// TabSpaces is returned.
+func (s Style) GetTabMode() TabMode {
+	return s.getAsTabMode()
+}
+
 // GetUnderlineSpaces returns whether or not the style is set to underline
 // spaces. If not value is set false is returned.
 func (s Style) GetUnderlineSpaces() bool {
@@ -512,6 +518,13 @@ func (s Style) getAsPosition(k propKey) Position {
 	return Position(0)
 }
 
+func (s Style) getAsTabMode() TabMode {
+	if !s.isSet(tabModeKey) {
+		return TabSpaces
+	}
+	return s.tabMode
+}
+
 func (s Style) getBorderStyle() Border {
 	if !s.isSet(borderStyleKey) {
 		return noBorder
@@ -519,6 +532,17 @@ func (s Style) getBorderStyle() Border {
 	return s.borderStyle
 }
 
+// getRenderedBorderStyle returns the border style as it's rendered, with
+// ASCII characters if the renderer is set to, or if the terminal can't display
+// box drawing characters.
+func (s Style) getRenderedBorderStyle() Border {
+	b := s.getBorderStyle()
+	if r := s.getRenderer(); r.ASCIIBorders() || !r.Capabilities().UnicodeBoxDrawing {
+		return b.asciiFallback()
+	}
+	return b
+}
+
 // Returns whether or not the style has implicit borders. This happens when
 // a border style has been set but no border sides have been explicitly turned
 // on or off.
@@ -540,17 +564,11 @@ func (s Style) getAsTransform(propKey) func(string) string {
 	return s.transform
 }
 
-// Split a string into lines, additionally returning the size of the widest
-// line.
-func getLines(s string) (lines []string, widest int) {
-	lines = strings.Split(s, "\n")
-
-	for _, l := range lines {
-		w := ansi.StringWidth(l)
-		if widest < w {
-			widest = w
-		}
+// getRenderer returns the style's renderer, or the default renderer if none
+// is set.
+func (s Style) getRenderer() *Renderer {
+	if s.r == nil {
+		return DefaultRenderer()
 	}
-
-	return lines, widest
+	return s.r
 }
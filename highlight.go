@@ -0,0 +1,92 @@
+package lipgloss
+
+import (
+	"regexp"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
+)
+
+// HighlightMatches applies a style to every match of a regular expression in
+// the string. Matching is done on the text with ANSI escape sequences
+// removed, and the style is layered on top of any existing styling with
+// [StyleRanges].
+func HighlightMatches(s string, re *regexp.Regexp, style Style) string {
+	plain := ansi.Strip(s)
+	matches := re.FindAllStringIndex(plain, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	starts, ends := cellOffsets(style.getRenderer(), plain)
+	ranges := make([]Range, 0, len(matches))
+	for _, m := range matches {
+		if m[0] == m[1] {
+			continue
+		}
+		ranges = append(ranges, NewRange(starts[m[0]], ends[m[1]], style))
+	}
+
+	return StyleRanges(s, ranges...)
+}
+
+// SubstringOption configures how [HighlightSubstring] matches.
+type SubstringOption func(*substringOptions)
+
+type substringOptions struct {
+	ignoreCase bool
+}
+
+// WithIgnoreCase makes [HighlightSubstring] match regardless of case, using
+// Unicode case folding.
+func WithIgnoreCase() SubstringOption {
+	return func(o *substringOptions) {
+		o.ignoreCase = true
+	}
+}
+
+// HighlightSubstring applies a style to every occurrence of substr in the
+// string. Matching is case-sensitive unless [WithIgnoreCase] is given. Like
+// [HighlightMatches], it matches the text with ANSI escape sequences removed
+// and keeps any existing styling.
+func HighlightSubstring(s, substr string, style Style, opts ...SubstringOption) string {
+	if substr == "" {
+		return s
+	}
+
+	var o substringOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	expr := regexp.QuoteMeta(substr)
+	if o.ignoreCase {
+		expr = "(?i)" + expr
+	}
+	return HighlightMatches(s, regexp.MustCompile(expr), style)
+}
+
+// cellOffsets maps the byte offsets of a string without escape sequences to
+// cell columns. starts holds the column at which the grapheme containing each
+// byte begins, and ends the column at which it ends, so a byte range [i, j)
+// covers the cells [starts[i], ends[j]). Widths are measured with the
+// renderer's width method.
+func cellOffsets(r *Renderer, plain string) (starts, ends []int) {
+	starts = make([]int, len(plain)+1)
+	ends = make([]int, len(plain)+1)
+
+	var col int
+	g := uniseg.NewGraphemes(plain)
+	for g.Next() {
+		from, to := g.Positions()
+		width := r.stringWidth(g.Str())
+		for i := from; i < to; i++ {
+			starts[i] = col
+			ends[i+1] = col + width
+		}
+		col += width
+	}
+	starts[len(plain)] = col
+
+	return starts, ends
+}
@@ -0,0 +1,121 @@
+package lipgloss
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	DefaultRenderer().SetColorProfile(termenv.ANSI)
+	style := NewStyle().Reverse(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		re       string
+		expected string
+	}{
+		{
+			name:     "no matches",
+			input:    "hello world",
+			re:       `x+`,
+			expected: "hello world",
+		},
+		{
+			name:     "multiple matches",
+			input:    "hello world",
+			re:       `o`,
+			expected: "hell\x1b[7mo\x1b[0m w\x1b[7mo\x1b[0mrld",
+		},
+		{
+			name:     "existing styling",
+			input:    "\x1b[32mERROR\x1b[0m: disk full",
+			re:       `ERR|full`,
+			expected: "\x1b[32m\x1b[7mERR\x1b[0m\x1b[32mOR\x1b[0m: disk \x1b[7mfull\x1b[0m",
+		},
+		{
+			name:     "wide characters",
+			input:    "你好 world",
+			re:       `好 w`,
+			expected: "你\x1b[7m好 w\x1b[0morld",
+		},
+		{
+			name:     "match inside a grapheme",
+			input:    "cafe\u0301!",
+			re:       `e`,
+			expected: "caf\x1b[7me\u0301\x1b[0m!",
+		},
+		{
+			name:     "empty matches",
+			input:    "abc",
+			re:       `x*`,
+			expected: "abc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := HighlightMatches(tc.input, regexp.MustCompile(tc.re), style)
+			if res != tc.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+}
+
+func TestHighlightSubstring(t *testing.T) {
+	DefaultRenderer().SetColorProfile(termenv.ANSI)
+	style := NewStyle().Bold(true)
+
+	tests := []struct {
+		name     string
+		input    string
+		substr   string
+		opts     []SubstringOption
+		expected string
+	}{
+		{
+			name:     "case-sensitive",
+			input:    "Go go GO",
+			substr:   "go",
+			expected: "Go \x1b[1mgo\x1b[0m GO",
+		},
+		{
+			name:     "ignore case",
+			input:    "Go go GO",
+			substr:   "go",
+			opts:     []SubstringOption{WithIgnoreCase()},
+			expected: "\x1b[1mGo\x1b[0m \x1b[1mgo\x1b[0m \x1b[1mGO\x1b[0m",
+		},
+		{
+			name:     "ignore case folds unicode",
+			input:    "Straße STRASSE straße",
+			substr:   "STRAßE",
+			opts:     []SubstringOption{WithIgnoreCase()},
+			expected: "\x1b[1mStraße\x1b[0m STRASSE \x1b[1mstraße\x1b[0m",
+		},
+		{
+			name:     "special characters",
+			input:    "a.b axb",
+			substr:   "a.b",
+			expected: "\x1b[1ma.b\x1b[0m axb",
+		},
+		{
+			name:     "empty substring",
+			input:    "abc",
+			substr:   "",
+			expected: "abc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := HighlightSubstring(tc.input, tc.substr, style, tc.opts...)
+			if res != tc.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+}
@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/charmbracelet/x/ansi"
 )
 
 // JoinHorizontal is a utility function for horizontally joining two
@@ -26,6 +24,37 @@ import (
 //	// Join on the top edge
 //	str := lipgloss.JoinHorizontal(lipgloss.Top, blockA, blockB)
 func JoinHorizontal(pos Position, strs ...string) string {
+	return DefaultRenderer().JoinHorizontalWith(pos, strs)
+}
+
+// JoinHorizontal horizontally joins multi-lined strings like the
+// package-level JoinHorizontal, measuring them with the renderer's width
+// method.
+func (r *Renderer) JoinHorizontal(pos Position, strs ...string) string {
+	return r.JoinHorizontalWith(pos, strs)
+}
+
+// JoinHorizontalWith is like JoinHorizontal, but takes options to add a gap or
+// a separator between blocks, or to override the position of specific
+// blocks.
+//
+// Example:
+//
+//	rule := lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render("│")
+//
+//	// Join with a rule that stretches to the height of the tallest block,
+//	// with a space on either side of it.
+//	str := lipgloss.JoinHorizontalWith(lipgloss.Top, []string{blockA, blockB},
+//	    lipgloss.WithJoinSeparator(rule),
+//	    lipgloss.WithJoinGap(1),
+//	)
+func JoinHorizontalWith(pos Position, strs []string, opts ...JoinOption) string {
+	return DefaultRenderer().JoinHorizontalWith(pos, strs, opts...)
+}
+
+// JoinHorizontalWith is like Renderer.JoinHorizontal, but takes options. See
+// the package-level JoinHorizontalWith.
+func (r *Renderer) JoinHorizontalWith(pos Position, strs []string, opts ...JoinOption) string {
 	if len(strs) == 0 {
 		return ""
 	}
@@ -33,6 +62,8 @@ func JoinHorizontal(pos Position, strs ...string) string {
 		return strs[0]
 	}
 
+	o := newJoinOptions(opts...)
+
 	var (
 		// Groups of strings broken into multiple lines
 		blocks = make([][]string, len(strs))
@@ -46,7 +77,7 @@ func JoinHorizontal(pos Position, strs ...string) string {
 
 	// Break text blocks into lines and get max widths for each text block
 	for i, str := range strs {
-		blocks[i], maxWidths[i] = getLines(str)
+		blocks[i], maxWidths[i] = r.getLines(str)
 		if len(blocks[i]) > maxHeight {
 			maxHeight = len(blocks[i])
 		}
@@ -60,7 +91,7 @@ func JoinHorizontal(pos Position, strs ...string) string {
 
 		extraLines := make([]string, maxHeight-len(blocks[i]))
 
-		switch pos { //nolint:exhaustive
+		switch pos := o.position(i, pos); pos { //nolint:exhaustive
 		case Top:
 			blocks[i] = append(blocks[i], extraLines...)
 
@@ -78,14 +109,26 @@ func JoinHorizontal(pos Position, strs ...string) string {
 		}
 	}
 
+	// Build the column that goes between blocks, if any.
+	between := o.horizontalSeparator(r, maxHeight)
+
+	// Collapse borders shared by adjacent blocks into junctions.
+	if o.junctions != nil && between == nil {
+		blocks, maxWidths = o.junctions.mergeBlocks(r, blocks, maxWidths)
+	}
+
 	// Merge lines
 	var b strings.Builder
 	for i := range blocks[0] { // remember, all blocks have the same number of members now
 		for j, block := range blocks {
+			if j > 0 && between != nil {
+				b.WriteString(between[i])
+			}
+
 			b.WriteString(block[i])
 
 			// Also make lines the same length
-			b.WriteString(strings.Repeat(" ", maxWidths[j]-ansi.StringWidth(block[i])))
+			b.WriteString(strings.Repeat(" ", maxWidths[j]-r.stringWidth(block[i])))
 		}
 		if i < len(blocks[0])-1 {
 			b.WriteRune('\n')
@@ -114,6 +157,33 @@ func JoinHorizontal(pos Position, strs ...string) string {
 //	// Join on the right edge
 //	str := lipgloss.JoinVertical(lipgloss.Right, blockA, blockB)
 func JoinVertical(pos Position, strs ...string) string {
+	return DefaultRenderer().JoinVerticalWith(pos, strs)
+}
+
+// JoinVertical vertically joins multi-lined strings like the package-level
+// JoinVertical, measuring them with the renderer's width method.
+func (r *Renderer) JoinVertical(pos Position, strs ...string) string {
+	return r.JoinVerticalWith(pos, strs)
+}
+
+// JoinVerticalWith is like JoinVertical, but takes options to add a gap or a
+// separator between blocks, or to override the position of specific blocks.
+//
+// Example:
+//
+//	// Join with a horizontal rule as wide as the widest block, and center
+//	// the second block regardless of the overall position.
+//	str := lipgloss.JoinVerticalWith(lipgloss.Left, []string{blockA, blockB},
+//	    lipgloss.WithJoinSeparator("─"),
+//	    lipgloss.WithJoinBlockPosition(1, lipgloss.Center),
+//	)
+func JoinVerticalWith(pos Position, strs []string, opts ...JoinOption) string {
+	return DefaultRenderer().JoinVerticalWith(pos, strs, opts...)
+}
+
+// JoinVerticalWith is like Renderer.JoinVertical, but takes options. See the
+// package-level JoinVerticalWith.
+func (r *Renderer) JoinVerticalWith(pos Position, strs []string, opts ...JoinOption) string {
 	if len(strs) == 0 {
 		return ""
 	}
@@ -121,6 +191,8 @@ func JoinVertical(pos Position, strs ...string) string {
 		return strs[0]
 	}
 
+	o := newJoinOptions(opts...)
+
 	var (
 		blocks   = make([][]string, len(strs))
 		maxWidth int
@@ -128,29 +200,30 @@ func JoinVertical(pos Position, strs ...string) string {
 
 	for i := range strs {
 		var w int
-		blocks[i], w = getLines(strs[i])
+		blocks[i], w = r.getLines(strs[i])
 		if w > maxWidth {
 			maxWidth = w
 		}
 	}
 
-	var b strings.Builder
+	// Build the rows that go between blocks, if any.
+	between := o.verticalSeparator(r, maxWidth)
+
+	// Align lines within the width of the widest block.
 	for i, block := range blocks {
+		pos := o.position(i, pos)
 		for j, line := range block {
-			w := maxWidth - ansi.StringWidth(line)
+			w := maxWidth - r.stringWidth(line)
 
 			switch pos { //nolint:exhaustive
 			case Left:
-				b.WriteString(line)
-				b.WriteString(strings.Repeat(" ", w))
+				line += strings.Repeat(" ", w)
 
 			case Right:
-				b.WriteString(strings.Repeat(" ", w))
-				b.WriteString(line)
+				line = strings.Repeat(" ", w) + line
 
 			default: // Somewhere in the middle
 				if w < 1 {
-					b.WriteString(line)
 					break
 				}
 
@@ -158,18 +231,167 @@ func JoinVertical(pos Position, strs ...string) string {
 				right := w - split
 				left := w - right
 
-				b.WriteString(strings.Repeat(" ", left))
-				b.WriteString(line)
-				b.WriteString(strings.Repeat(" ", right))
+				line = strings.Repeat(" ", left) + line + strings.Repeat(" ", right)
 			}
 
-			// Write a newline as long as we're not on the last line of the
-			// last block.
-			if !(i == len(blocks)-1 && j == len(block)-1) { //nolint:staticcheck
-				b.WriteRune('\n')
+			block[j] = line
+		}
+	}
+
+	var lines []string
+	for i, block := range blocks {
+		if i > 0 {
+			// Collapse borders shared by adjacent blocks into junctions.
+			if o.junctions != nil && len(between) == 0 {
+				last := len(lines) - 1
+				if line, ok := o.junctions.mergeVertical(lines[last], block[0]); ok {
+					lines[last] = line
+					block = block[1:]
+				}
 			}
+			lines = append(lines, between...)
 		}
+		lines = append(lines, block...)
 	}
 
-	return b.String()
+	return strings.Join(lines, "\n")
+}
+
+// JoinOption sets a rule for joining blocks with JoinHorizontalWith and
+// JoinVerticalWith.
+type JoinOption func(*joinOptions)
+
+type joinOptions struct {
+	gap       int
+	separator string
+	positions map[int]Position
+	junctions *junctions
+}
+
+func newJoinOptions(opts ...JoinOption) joinOptions {
+	var o joinOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithJoinGap sets the number of cells (when joining horizontally) or lines
+// (when joining vertically) between blocks. When a separator is set, the gap
+// is added on both sides of it.
+func WithJoinGap(n int) JoinOption {
+	return func(o *joinOptions) {
+		o.gap = max(0, n)
+	}
+}
+
+// WithJoinSeparator sets a string to render between blocks. It may be styled.
+//
+// When joining horizontally the separator is stretched to the height of the
+// tallest block. If it has several lines, they are repeated in order. When
+// joining vertically, the separator is repeated to the width of the widest
+// block.
+func WithJoinSeparator(sep string) JoinOption {
+	return func(o *joinOptions) {
+		o.separator = sep
+	}
+}
+
+// WithJoinBlockPosition overrides the position of the block at index i,
+// counting from zero.
+func WithJoinBlockPosition(i int, pos Position) JoinOption {
+	return func(o *joinOptions) {
+		if o.positions == nil {
+			o.positions = make(map[int]Position)
+		}
+		o.positions[i] = pos
+	}
+}
+
+// WithMergedBorders collapses borders shared by adjacent blocks into one,
+// using the junction runes of the given border, such as MiddleTop, Middle and
+// MiddleLeft, where they meet. This makes separately bordered blocks look
+// like a single frame split into panes.
+//
+// Borders are only merged when the touching edges of both blocks are made up
+// of runes from the given border, and no gap or separator is set.
+//
+// Example:
+//
+//	box := lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+//	str := lipgloss.JoinHorizontalWith(lipgloss.Top,
+//	    []string{box.Render("Tab 1"), box.Render("Tab 2")},
+//	    lipgloss.WithMergedBorders(lipgloss.NormalBorder()),
+//	)
+//
+//	// ┌─────┬─────┐
+//	// │Tab 1│Tab 2│
+//	// └─────┴─────┘
+func WithMergedBorders(b Border) JoinOption {
+	return func(o *joinOptions) {
+		j := newJunctions(b)
+		o.junctions = &j
+	}
+}
+
+// position returns the position of the block at index i.
+func (o joinOptions) position(i int, pos Position) Position {
+	if p, ok := o.positions[i]; ok {
+		return p
+	}
+	return pos
+}
+
+// horizontalSeparator returns the lines to write between blocks joined
+// horizontally, or nil if nothing goes between them.
+func (o joinOptions) horizontalSeparator(r *Renderer, height int) []string {
+	if o.gap == 0 && o.separator == "" {
+		return nil
+	}
+
+	gap := strings.Repeat(" ", o.gap)
+	sepLines, sepWidth := r.getLines(o.separator)
+
+	lines := make([]string, height)
+	for i := range lines {
+		if o.separator == "" {
+			lines[i] = gap
+			continue
+		}
+		l := sepLines[i%len(sepLines)]
+		l += strings.Repeat(" ", sepWidth-r.stringWidth(l))
+		lines[i] = gap + l + gap
+	}
+	return lines
+}
+
+// verticalSeparator returns the lines to write between blocks joined
+// vertically.
+func (o joinOptions) verticalSeparator(r *Renderer, width int) []string {
+	blank := strings.Repeat(" ", width)
+
+	var lines []string
+	for i := 0; i < o.gap; i++ {
+		lines = append(lines, blank)
+	}
+	if o.separator != "" {
+		lines = append(lines, repeatToWidth(r, o.separator, width))
+		for i := 0; i < o.gap; i++ {
+			lines = append(lines, blank)
+		}
+	}
+	return lines
+}
+
+// repeatToWidth repeats the first line of str until it fills the given width.
+// Cells a wide character doesn't fit in are filled with spaces.
+func repeatToWidth(r *Renderer, str string, width int) string {
+	str, _, _ = strings.Cut(str, "\n")
+	w := r.stringWidth(str)
+	if w == 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	line := r.truncate(strings.Repeat(str, width/w+1), width)
+	return line + strings.Repeat(" ", width-r.stringWidth(line))
 }
@@ -43,3 +43,130 @@ func TestJoinHorizontal(t *testing.T) {
 		})
 	}
 }
+
+func TestJoinWithOptions(t *testing.T) {
+	type test struct {
+		name     string
+		result   string
+		expected string
+	}
+	tests := []test{
+		{
+			"horizontal gap",
+			JoinHorizontalWith(Top, []string{"A", "B\nB"}, WithJoinGap(2)),
+			"A  B\n   B",
+		},
+		{
+			"horizontal separator",
+			JoinHorizontalWith(Top, []string{"A", "B\nB\nB"}, WithJoinSeparator("│"), WithJoinGap(1)),
+			"A │ B\n  │ B\n  │ B",
+		},
+		{
+			"horizontal multi-line separator",
+			JoinHorizontalWith(Top, []string{"AA\nAA\nAA", "B"}, WithJoinSeparator("┬\n│")),
+			"AA┬B\nAA│ \nAA┬ ",
+		},
+		{
+			"horizontal block position",
+			JoinHorizontalWith(Top, []string{"A", "B\nB\nB", "C"}, WithJoinBlockPosition(2, Bottom)),
+			"AB \n B \n BC",
+		},
+		{
+			"vertical gap",
+			JoinVerticalWith(Left, []string{"A", "BB"}, WithJoinGap(1)),
+			"A \n  \nBB",
+		},
+		{
+			"vertical separator",
+			JoinVerticalWith(Right, []string{"A", "BBBB"}, WithJoinSeparator("-=")),
+			"   A\n-=-=\nBBBB",
+		},
+		{
+			"vertical block position",
+			JoinVerticalWith(Left, []string{"A", "BBB", "C"}, WithJoinBlockPosition(1, Right), WithJoinBlockPosition(2, Center)),
+			"A  \nBBB\n C ",
+		},
+		{
+			"single block",
+			JoinVerticalWith(Left, []string{"A"}, WithJoinSeparator("-")),
+			"A",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.result != test.expected {
+				t.Errorf("Got \n%s\n, expected \n%s\n", test.result, test.expected)
+			}
+		})
+	}
+}
+
+func TestJoinWithMergedBorders(t *testing.T) {
+	box := NewStyle().Border(NormalBorder())
+	a := box.Render("Tab 1")
+	b := box.Render("Tab 2\nmore")
+	c := box.Render("wide wide")
+
+	type test struct {
+		name     string
+		result   string
+		expected string
+	}
+	tests := []test{
+		{
+			"horizontal top",
+			JoinHorizontalWith(Top, []string{a, b}, WithMergedBorders(NormalBorder())),
+			"┌─────┬─────┐\n│Tab 1│Tab 2│\n└─────┤more │\n      └─────┘",
+		},
+		{
+			"horizontal bottom",
+			JoinHorizontalWith(Bottom, []string{a, b}, WithMergedBorders(NormalBorder())),
+			"      ┌─────┐\n┌─────┤Tab 2│\n│Tab 1│more │\n└─────┴─────┘",
+		},
+		{
+			"vertical",
+			JoinVerticalWith(Left, []string{a, b, c}, WithMergedBorders(NormalBorder())),
+			"┌─────┐    \n│Tab 1│    \n├─────┤    \n│Tab 2│    \n│more │    \n├─────┴───┐\n│wide wide│\n└─────────┘",
+		},
+		{
+			"not a border",
+			JoinHorizontalWith(Top, []string{a, "x"}, WithMergedBorders(NormalBorder())),
+			"┌─────┐x\n│Tab 1│ \n└─────┘ ",
+		},
+		{
+			"different border",
+			JoinHorizontalWith(Top, []string{a, a}, WithMergedBorders(ASCIIBorder())),
+			"┌─────┐┌─────┐\n│Tab 1││Tab 1│\n└─────┘└─────┘",
+		},
+		{
+			"ascii",
+			JoinVerticalWith(Left, []string{
+				NewStyle().Border(ASCIIBorder()).Render("a"),
+				NewStyle().Border(ASCIIBorder()).Render("bbb"),
+			}, WithMergedBorders(ASCIIBorder())),
+			"+-+  \n|a|  \n+-+-+\n|bbb|\n+---+",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.result != test.expected {
+				t.Errorf("Got \n%s\n, expected \n%s\n", test.result, test.expected)
+			}
+		})
+	}
+}
+
+func TestJoinWithMergedStyledBorders(t *testing.T) {
+	j := newJunctions(NormalBorder())
+
+	got, ok := j.mergeVertical("\x1b[31m└─┘\x1b[0m ", "\x1b[32m┌──┐\x1b[0m")
+	if !ok {
+		t.Fatal("expected lines to merge")
+	}
+	expected := "\x1b[31m├─┴\x1b[m\x1b[32m┐\x1b[0m"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
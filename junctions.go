@@ -0,0 +1,261 @@
+package lipgloss
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Directions a box-drawing rune connects to.
+type junction uint8
+
+const (
+	junctionUp junction = 1 << iota
+	junctionDown
+	junctionLeft
+	junctionRight
+)
+
+// junctions maps the runes of a border to the directions they connect to, and
+// back.
+type junctions struct {
+	dirs  map[string]junction
+	runes map[junction]string
+}
+
+func newJunctions(b Border) junctions {
+	j := junctions{
+		dirs:  make(map[string]junction),
+		runes: make(map[junction]string),
+	}
+
+	for _, p := range []struct {
+		rune string
+		dirs junction
+	}{
+		{b.Left, junctionUp | junctionDown},
+		{b.Right, junctionUp | junctionDown},
+		{b.Top, junctionLeft | junctionRight},
+		{b.Bottom, junctionLeft | junctionRight},
+		{b.TopLeft, junctionRight | junctionDown},
+		{b.TopRight, junctionLeft | junctionDown},
+		{b.BottomLeft, junctionRight | junctionUp},
+		{b.BottomRight, junctionLeft | junctionUp},
+		{b.MiddleLeft, junctionUp | junctionDown | junctionRight},
+		{b.MiddleRight, junctionUp | junctionDown | junctionLeft},
+		{b.MiddleTop, junctionLeft | junctionRight | junctionDown},
+		{b.MiddleBottom, junctionLeft | junctionRight | junctionUp},
+		{b.Middle, junctionUp | junctionDown | junctionLeft | junctionRight},
+	} {
+		// Spaces can't be told apart from padding, so they never merge.
+		if p.rune == "" || p.rune == " " {
+			continue
+		}
+		// Borders such as ASCIIBorder reuse the same rune for several
+		// parts, in which case it connects in all of their directions.
+		j.dirs[p.rune] |= p.dirs
+		if _, ok := j.runes[p.dirs]; !ok {
+			j.runes[p.dirs] = p.rune
+		}
+	}
+
+	return j
+}
+
+// merge returns the rune that joins a and b. If the border has no rune for
+// the combined directions, its Middle rune is used, or a if that's missing
+// too.
+func (j junctions) merge(a, b string) string {
+	if r, ok := j.runes[j.dirs[a]|j.dirs[b]]; ok {
+		return r
+	}
+	if r, ok := j.runes[junctionUp|junctionDown|junctionLeft|junctionRight]; ok {
+		return r
+	}
+	return a
+}
+
+// mergeable reports whether a and b are border runes or blank.
+func (j junctions) mergeable(a, b string) bool {
+	_, okA := j.dirs[a]
+	_, okB := j.dirs[b]
+	return (okA || a == " ") && (okB || b == " ")
+}
+
+// cell is a single printable cell of a line, along with the escape sequences
+// that precede it.
+type cell struct {
+	seq  string
+	text string
+}
+
+// splitCells splits a line into cells, returning the escape sequences that
+// trail the last cell separately.
+func splitCells(line string) (cells []cell, tail string) {
+	var (
+		seq   strings.Builder
+		state byte
+	)
+	for len(line) > 0 {
+		s, width, n, newState := ansi.DecodeSequence(line, state, nil)
+		if width > 0 {
+			cells = append(cells, cell{seq: seq.String(), text: s})
+			seq.Reset()
+		} else {
+			seq.WriteString(s)
+		}
+		state = newState
+		line = line[n:]
+	}
+	return cells, seq.String()
+}
+
+// joinCells joins cells back into a line.
+func joinCells(cells []cell, tail string) string {
+	var b strings.Builder
+	for _, c := range cells {
+		b.WriteString(c.seq)
+		b.WriteString(c.text)
+	}
+	b.WriteString(tail)
+	return b.String()
+}
+
+// mergeBlocks collapses the borders shared by adjacent blocks of equal height,
+// returning the merged blocks and their widths.
+func (j junctions) mergeBlocks(r *Renderer, blocks [][]string, widths []int) ([][]string, []int) {
+	var (
+		merged       = [][]string{padLines(r, blocks[0], widths[0])}
+		mergedWidths = []int{widths[0]}
+	)
+	for i := 1; i < len(blocks); i++ {
+		block := padLines(r, blocks[i], widths[i])
+		last := len(merged) - 1
+		if lines, ok := j.mergeHorizontal(merged[last], block); ok {
+			merged[last] = lines
+			mergedWidths[last] += widths[i] - 1
+			continue
+		}
+		merged = append(merged, block)
+		mergedWidths = append(mergedWidths, widths[i])
+	}
+	return merged, mergedWidths
+}
+
+// padLines pads lines with spaces to the given width, measured with the
+// renderer's width method.
+func padLines(r *Renderer, lines []string, width int) []string {
+	padded := make([]string, len(lines))
+	for i, l := range lines {
+		padded[i] = l + strings.Repeat(" ", max(0, width-r.stringWidth(l)))
+	}
+	return padded
+}
+
+// mergeHorizontal joins two blocks of equal height side by side, collapsing
+// the last column of a and the first column of b into one. It reports false
+// if the columns aren't made up of border runes.
+func (j junctions) mergeHorizontal(a, b []string) ([]string, bool) {
+	var (
+		left       = make([][]cell, len(a))
+		right      = make([][]cell, len(b))
+		leftTails  = make([]string, len(a))
+		rightTails = make([]string, len(b))
+		shared     bool
+	)
+	for i := range a {
+		left[i], leftTails[i] = splitCells(a[i])
+		right[i], rightTails[i] = splitCells(b[i])
+		if len(left[i]) == 0 || len(right[i]) == 0 {
+			return nil, false
+		}
+
+		l, r := left[i][len(left[i])-1].text, right[i][0].text
+		if !j.mergeable(l, r) {
+			return nil, false
+		}
+		shared = shared || (l != " " && r != " ")
+	}
+	if !shared {
+		return nil, false
+	}
+
+	lines := make([]string, len(a))
+	for i := range a {
+		last := len(left[i]) - 1
+		l, r := left[i][last].text, right[i][0].text
+
+		if l == " " {
+			// Keep the right block's rune and its styling.
+			lines[i] = joinCells(left[i][:last], left[i][last].seq+leftTails[i]) + b[i]
+			continue
+		}
+
+		left[i][last].text = j.merge(l, r)
+		lines[i] = joinCells(left[i], leftTails[i]) +
+			right[i][0].seq + joinCells(right[i][1:], rightTails[i])
+	}
+
+	return lines, true
+}
+
+// mergeVertical stacks two lines of equal width, collapsing them into one. It
+// reports false if the lines aren't made up of border runes.
+func (j junctions) mergeVertical(a, b string) (string, bool) {
+	top, topTail := splitCells(a)
+	bottom, bottomTail := splitCells(b)
+	if len(top) != len(bottom) {
+		return "", false
+	}
+
+	var shared bool
+	for i := range top {
+		if !j.mergeable(top[i].text, bottom[i].text) {
+			return "", false
+		}
+		shared = shared || (top[i].text != " " && bottom[i].text != " ")
+	}
+	if !shared {
+		return "", false
+	}
+
+	var (
+		out    strings.Builder
+		styled = strings.ContainsRune(a+b, ansi.ESC)
+		fromB  bool
+	)
+	for i := range top {
+		c := top[i]
+		useB := c.text == " " && bottom[i].text != " "
+		if useB {
+			c = bottom[i]
+		} else if bottom[i].text != " " {
+			c.text = j.merge(c.text, bottom[i].text)
+		}
+
+		// When switching lines, restore the styling of the line we're
+		// switching to.
+		if styled && useB != fromB {
+			src := top
+			if useB {
+				src = bottom
+			}
+			out.WriteString(ansi.ResetStyle)
+			for _, p := range src[:i] {
+				out.WriteString(p.seq)
+			}
+		}
+		fromB = useB
+
+		out.WriteString(c.seq)
+		out.WriteString(c.text)
+	}
+
+	if fromB {
+		out.WriteString(bottomTail)
+	} else {
+		out.WriteString(topTail)
+	}
+
+	return out.String(), true
+}
@@ -0,0 +1,236 @@
+// Package lipglosstest provides helpers for testing lipgloss output: a
+// renderer that renders the same way on every machine, golden file
+// assertions with readable diffs, and size assertions.
+package lipglosstest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/muesli/termenv"
+	"github.com/rhystmorgan/lipgloss"
+)
+
+// Option configures a renderer created with NewRenderer.
+type Option func(*lipgloss.Renderer)
+
+// WithColorProfile sets the color profile of the renderer. The default is
+// TrueColor.
+func WithColorProfile(p termenv.Profile) Option {
+	return func(r *lipgloss.Renderer) {
+		r.SetColorProfile(p)
+	}
+}
+
+// WithDarkBackground sets whether the renderer has a dark background. The
+// default is true.
+func WithDarkBackground(v bool) Option {
+	return func(r *lipgloss.Renderer) {
+		r.SetHasDarkBackground(v)
+	}
+}
+
+// WithWidthMethod sets the width method of the renderer. The default is
+// GraphemeWidth.
+func WithWidthMethod(m lipgloss.WidthMethod) Option {
+	return func(r *lipgloss.Renderer) {
+		r.SetWidthMethod(m)
+	}
+}
+
+// NewRenderer returns a renderer that doesn't depend on the terminal or the
+// environment the tests run in. Unless configured otherwise, it renders in
+// TrueColor on a dark background, measures text with GraphemeWidth, and
+// supports every capability.
+func NewRenderer(opts ...Option) *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+	r.SetWidthMethod(lipgloss.GraphemeWidth)
+	r.SetCapabilities(lipgloss.Capabilities{
+		Italic:             true,
+		Strikethrough:      true,
+		Hyperlinks:         true,
+		StyledUnderlines:   true,
+		SynchronizedOutput: true,
+		GraphemeWidth:      true,
+		UnicodeBoxDrawing:  true,
+	})
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// RequireEqual asserts that out matches the golden file of the test, found
+// at testdata/<test name>.golden. If it doesn't, the test fails with a diff
+// of the two in which escape sequences are shown with Visualize.
+//
+// Golden files contain the raw output and are compatible with the ones of
+// github.com/charmbracelet/x/exp/golden. Run the tests with the -update flag
+// to update them.
+func RequireEqual[T []byte | string](tb testing.TB, out T) {
+	tb.Helper()
+
+	if f := flag.Lookup("update"); f != nil && f.Value.String() == "true" {
+		golden.RequireEqual(tb, out)
+		return
+	}
+
+	path := filepath.Join("testdata", tb.Name()+".golden")
+	want, err := os.ReadFile(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	expected := strings.ReplaceAll(string(want), "\r\n", "\n")
+	if got := string(out); got != expected {
+		diff := udiff.Unified("golden", "got", Visualize(expected), Visualize(got))
+		tb.Fatalf("output does not match %s:\n\n%s", path, diff)
+	}
+}
+
+// RequireWidth asserts that the widest line of s is width cells wide,
+// measured with the width method of r.
+func RequireWidth(tb testing.TB, r *lipgloss.Renderer, s string, width int) {
+	tb.Helper()
+	if w := r.Width(s); w != width {
+		tb.Fatalf("expected width %d, got %d:\n\n%s", width, w, Visualize(s))
+	}
+}
+
+// RequireHeight asserts that s is height lines tall.
+func RequireHeight(tb testing.TB, s string, height int) {
+	tb.Helper()
+	if h := lipgloss.Height(s); h != height {
+		tb.Fatalf("expected height %d, got %d:\n\n%s", height, h, Visualize(s))
+	}
+}
+
+// RequireSize asserts that s is width cells wide and height lines tall, and
+// that all of its lines are equally wide, as the output of Style.Render is.
+// Widths are measured with the width method of r.
+func RequireSize(tb testing.TB, r *lipgloss.Renderer, s string, width, height int) {
+	tb.Helper()
+	RequireHeight(tb, s, height)
+	for i, line := range strings.Split(s, "\n") {
+		if w := r.Width(line); w != width {
+			tb.Fatalf("expected line %d to be %d cells wide, got %d:\n\n%s",
+				i+1, width, w, Visualize(s))
+		}
+	}
+}
+
+// Visualize returns s with its escape sequences and control characters made
+// readable. SGR sequences are shown as the attributes they set, such as
+// {bold fg:#ff0000}, and other sequences are quoted.
+func Visualize(s string) string {
+	var (
+		b     strings.Builder
+		state byte
+	)
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+
+		switch {
+		case width > 0 || seq == "\n":
+			b.WriteString(seq)
+		case ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m"):
+			b.WriteString("{" + describeSGR(seq[2:len(seq)-1]) + "}")
+		default:
+			q := strconv.Quote(seq)
+			b.WriteString(q[1 : len(q)-1])
+		}
+	}
+	return b.String()
+}
+
+var sgrNames = map[string]string{
+	"":   "reset",
+	"0":  "reset",
+	"1":  "bold",
+	"2":  "faint",
+	"3":  "italic",
+	"4":  "underline",
+	"5":  "blink",
+	"7":  "reverse",
+	"8":  "conceal",
+	"9":  "strikethrough",
+	"22": "-bold",
+	"23": "-italic",
+	"24": "-underline",
+	"25": "-blink",
+	"27": "-reverse",
+	"28": "-conceal",
+	"29": "-strikethrough",
+	"39": "fg:default",
+	"49": "bg:default",
+	"53": "overline",
+	"55": "-overline",
+	"59": "ul:default",
+}
+
+// describeSGR returns a readable description of SGR parameters.
+func describeSGR(params string) string {
+	var (
+		names []string
+		parts = strings.Split(params, ";")
+	)
+	for i := 0; i < len(parts); i++ {
+		p := parts[i]
+		if name, ok := sgrNames[p]; ok {
+			names = append(names, name)
+			continue
+		}
+
+		code, err := strconv.Atoi(p)
+		switch {
+		case err != nil:
+			names = append(names, p)
+		case code >= 30 && code <= 37:
+			names = append(names, "fg:"+strconv.Itoa(code-30))
+		case code >= 90 && code <= 97:
+			names = append(names, "fg:"+strconv.Itoa(code-90+8))
+		case code >= 40 && code <= 47:
+			names = append(names, "bg:"+strconv.Itoa(code-40))
+		case code >= 100 && code <= 107:
+			names = append(names, "bg:"+strconv.Itoa(code-100+8))
+		case (code == 38 || code == 48 || code == 58) && i+2 < len(parts) && parts[i+1] == "5":
+			names = append(names, colorTarget(code)+parts[i+2])
+			i += 2
+		case (code == 38 || code == 48 || code == 58) && i+4 < len(parts) && parts[i+1] == "2":
+			var rgb [3]int
+			for j := range rgb {
+				rgb[j], _ = strconv.Atoi(parts[i+2+j])
+			}
+			names = append(names, fmt.Sprintf("%s#%02x%02x%02x", colorTarget(code), rgb[0], rgb[1], rgb[2]))
+			i += 4
+		default:
+			names = append(names, p)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// colorTarget returns the prefix describing what an extended color SGR
+// parameter colors.
+func colorTarget(code int) string {
+	switch code {
+	case 38:
+		return "fg:"
+	case 48:
+		return "bg:"
+	default:
+		return "ul:"
+	}
+}
@@ -0,0 +1,64 @@
+package lipglosstest
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+	"github.com/rhystmorgan/lipgloss"
+)
+
+func TestNewRenderer(t *testing.T) {
+	r := NewRenderer(WithColorProfile(termenv.ANSI256), WithDarkBackground(false))
+	if p := r.ColorProfile(); p != termenv.ANSI256 {
+		t.Errorf("expected ANSI256 profile, got %v", p)
+	}
+	if r.HasDarkBackground() {
+		t.Error("expected a light background")
+	}
+	if !r.Capabilities().Italic {
+		t.Error("expected italic to be supported")
+	}
+}
+
+func TestRequireEqual(t *testing.T) {
+	r := NewRenderer()
+	out := r.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#ff0000")).
+		Border(lipgloss.RoundedBorder()).
+		Padding(0, 1).
+		Render("Hello")
+
+	RequireEqual(t, out)
+	RequireSize(t, r, out, 9, 3)
+}
+
+func TestRequireSize(t *testing.T) {
+	r := NewRenderer()
+	RequireWidth(t, r, "你好\nhi", 4)
+	RequireHeight(t, "a\nb\nc", 3)
+	RequireSize(t, r, "\x1b[1mab\x1b[0m\ncd", 2, 2)
+
+	// Widths are measured with the renderer's width method.
+	r = NewRenderer(WithWidthMethod(lipgloss.WcWidth))
+	RequireWidth(t, r, "👩‍💻", 4)
+	RequireSize(t, r, r.NewStyle().Width(6).Render("👩‍💻"), 6, 1)
+}
+
+func TestVisualize(t *testing.T) {
+	tt := []struct {
+		input    string
+		expected string
+	}{
+		{"plain\ntext", "plain\ntext"},
+		{"\x1b[1;31mhi\x1b[0m", "{bold fg:1}hi{reset}"},
+		{"\x1b[38;2;255;0;0;48;5;21mhi\x1b[m", "{fg:#ff0000 bg:21}hi{reset}"},
+		{"\x1b[2Ka\tb", `\x1b[2Ka\tb`},
+	}
+
+	for _, tc := range tt {
+		if res := Visualize(tc.input); res != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, res)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package lipgloss
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// MinimalSGR returns whether the renderer minimizes SGR sequences.
+func (r *Renderer) MinimalSGR() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.minimalSGR
+}
+
+// SetMinimalSGR sets whether the renderer minimizes the SGR (Select Graphic
+// Rendition) sequences in the output of Style.Render, Place and
+// PlaceOverlay. Lines are minimized one by one and end with a reset, so the
+// output can be joined and composed like any other. See MinimizeSGR for
+// details.
+//
+// This function is thread-safe.
+func (r *Renderer) SetMinimalSGR(v bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.minimalSGR = v
+}
+
+// SetMinimalSGR sets whether the default renderer minimizes SGR sequences.
+//
+// This function is thread-safe.
+func SetMinimalSGR(v bool) {
+	DefaultRenderer().SetMinimalSGR(v)
+}
+
+// MinimizeSGR rewrites the SGR (Select Graphic Rendition) sequences in s so
+// that only changes to the text attributes and colors are emitted, right
+// before the text they apply to. Redundant resets, such as the ones between
+// runs of whitespace styled the same way, are dropped. The string renders
+// exactly as before.
+//
+// Every line that leaves the style changed ends with a reset, and styling
+// carried over from the previous line is set again where the next line's
+// text starts, so the lines can still be joined and composed with other
+// blocks.
+//
+// Sequences with parameters MinimizeSGR doesn't know about are kept as they
+// are.
+func MinimizeSGR(s string) string {
+	var (
+		b       strings.Builder
+		current pen // state of the terminal
+		wanted  pen // state the text that follows should be rendered with
+		state   byte
+
+		// Once a sequence with unknown parameters is seen, the state of the
+		// terminal is no longer known for certain: sequences are passed
+		// through as they are until the next reset, and kept in history to be
+		// replayed at the start of the following lines.
+		dirty   bool
+		history []string
+		replay  bool
+	)
+
+	// flush brings the terminal to the wanted state.
+	flush := func() {
+		if replay {
+			b.WriteString(strings.Join(history, ""))
+			current, replay = wanted, false
+		}
+		if current == wanted {
+			return
+		}
+		b.WriteString(current.transition(wanted))
+		current = wanted
+	}
+
+	// reset brings the terminal back to its default state.
+	reset := func() {
+		if current != (pen{}) || (dirty && !replay) {
+			b.WriteString(ansi.ResetStyle)
+		}
+		current = pen{}
+	}
+
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+
+		if width == 0 && isSGR(seq) && !strings.ContainsAny(seq, "<=>?") {
+			params := seq[2 : len(seq)-1]
+			next := wanted
+			ok := next.apply(params)
+			switch {
+			case dirty:
+				if params == "" || params == "0" {
+					// The state of the terminal is known again.
+					if !replay {
+						b.WriteString(seq)
+					}
+					current, wanted = pen{}, pen{}
+					dirty, history, replay = false, nil, false
+					continue
+				}
+				history = append(history, seq)
+				if !replay {
+					b.WriteString(seq)
+					current = next
+				}
+				wanted = next
+			case ok:
+				wanted = next
+			default:
+				flush()
+				history = []string{pen{}.transition(wanted), seq}
+				if wanted == (pen{}) {
+					history = history[1:]
+				}
+				b.WriteString(seq)
+				current, wanted = next, next
+				dirty = true
+			}
+			continue
+		}
+
+		switch {
+		case seq == "\n":
+			reset()
+			replay = dirty
+		case width == 0 && len(seq) == 1 && seq[0] < ' ':
+			// Other control characters only depend on the background color,
+			// which terminals use to fill lines when scrolling.
+			if current.bg != wanted.bg {
+				flush()
+			}
+		default:
+			flush()
+		}
+		b.WriteString(seq)
+	}
+
+	reset()
+	return b.String()
+}
+
+// pen holds the text attributes and colors set by SGR sequences. Each field
+// holds the parameters that set it, or is empty when it's off.
+type pen struct {
+	bold, faint, italic, underline, blink, reverse, conceal, strike, overline string
+	fg, bg, underlineColor                                                    string
+}
+
+// apply updates the pen with the given SGR parameters. It reports false if
+// the parameters contain ones it doesn't know about, in which case the pen
+// is only partially updated.
+func (p *pen) apply(params string) bool {
+	if params == "" {
+		*p = pen{}
+		return true
+	}
+
+	ok := true
+	parts := strings.Split(params, ";")
+	for i := 0; i < len(parts); i++ {
+		param := parts[i]
+		code, sub, hasSub := strings.Cut(param, ":")
+		switch code {
+		case "", "0":
+			*p = pen{}
+		case "1":
+			p.bold = param
+		case "2":
+			p.faint = param
+		case "3":
+			p.italic = param
+		case "4":
+			p.underline = param
+			if hasSub && sub == "0" {
+				p.underline = ""
+			}
+		case "21":
+			p.underline = param
+		case "5", "6":
+			p.blink = param
+		case "7":
+			p.reverse = param
+		case "8":
+			p.conceal = param
+		case "9":
+			p.strike = param
+		case "53":
+			p.overline = param
+		case "22":
+			p.bold, p.faint = "", ""
+		case "23":
+			p.italic = ""
+		case "24":
+			p.underline = ""
+		case "25":
+			p.blink = ""
+		case "27":
+			p.reverse = ""
+		case "28":
+			p.conceal = ""
+		case "29":
+			p.strike = ""
+		case "55":
+			p.overline = ""
+		case "39":
+			p.fg = ""
+		case "49":
+			p.bg = ""
+		case "59":
+			p.underlineColor = ""
+		case "38", "48", "58":
+			if !hasSub {
+				// Take the arguments of 38;5;n and 38;2;r;g;b along.
+				n := 0
+				if i+1 < len(parts) {
+					switch parts[i+1] {
+					case "5":
+						n = 2 //nolint:mnd
+					case "2":
+						n = 4 //nolint:mnd
+					}
+				}
+				if n == 0 || i+n >= len(parts) {
+					return false
+				}
+				param = strings.Join(parts[i:i+n+1], ";")
+				i += n
+			}
+			switch code {
+			case "38":
+				p.fg = param
+			case "48":
+				p.bg = param
+			default:
+				p.underlineColor = param
+			}
+		default:
+			switch {
+			case len(code) == 2 && (code[0] == '3' || code[0] == '9') && code[1] <= '7':
+				p.fg = param
+			case len(code) == 2 && code[0] == '4' && code[1] <= '7',
+				len(code) == 3 && code[:2] == "10" && code[2] <= '7':
+				p.bg = param
+			default:
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// params returns the SGR parameters that set the pen from a reset state.
+func (p pen) params() []string {
+	var params []string
+	for _, v := range []string{
+		p.bold, p.faint, p.italic, p.underline, p.blink, p.reverse, p.conceal,
+		p.strike, p.overline, p.fg, p.bg, p.underlineColor,
+	} {
+		if v != "" {
+			params = append(params, v)
+		}
+	}
+	return params
+}
+
+// transition returns the shortest SGR sequence that changes the pen to next.
+func (p pen) transition(next pen) string {
+	full := ansi.ResetStyle
+	if params := next.params(); len(params) > 0 {
+		full = "\x1b[0;" + strings.Join(params, ";") + "m"
+	}
+	var params []string
+	// Bold and faint are turned off together.
+	if (p.bold != "" && next.bold == "") || (p.faint != "" && next.faint == "") {
+		params = append(params, "22")
+		p.bold, p.faint = "", ""
+	}
+	for _, a := range []struct {
+		from, to, off string
+	}{
+		{p.bold, next.bold, ""},
+		{p.faint, next.faint, ""},
+		{p.italic, next.italic, "23"},
+		{p.underline, next.underline, "24"},
+		{p.blink, next.blink, "25"},
+		{p.reverse, next.reverse, "27"},
+		{p.conceal, next.conceal, "28"},
+		{p.strike, next.strike, "29"},
+		{p.overline, next.overline, "55"},
+		{p.fg, next.fg, "39"},
+		{p.bg, next.bg, "49"},
+		{p.underlineColor, next.underlineColor, "59"},
+	} {
+		switch {
+		case a.from == a.to:
+		case a.to == "":
+			params = append(params, a.off)
+		default:
+			params = append(params, a.to)
+		}
+	}
+
+	diff := "\x1b[" + strings.Join(params, ";") + "m"
+	if len(full) < len(diff) {
+		return full
+	}
+	return diff
+}
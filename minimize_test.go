@@ -0,0 +1,187 @@
+package lipgloss
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestMinimizeSGR(t *testing.T) {
+	tt := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no sequences",
+			input:    "hello",
+			expected: "hello",
+		},
+		{
+			name:     "lines end with a reset",
+			input:    "\x1b[31mfoo\x1b[0m \x1b[31mbar\x1b[0m\n\x1b[31mbaz\x1b[0m",
+			expected: "\x1b[31mfoo\x1b[m \x1b[31mbar\x1b[m\n\x1b[31mbaz\x1b[m",
+		},
+		{
+			name:     "background is reset before line breaks",
+			input:    "\x1b[41mfoo\x1b[0m\n\x1b[41mbar\x1b[0m",
+			expected: "\x1b[41mfoo\x1b[m\n\x1b[41mbar\x1b[m",
+		},
+		{
+			name:     "only changes are emitted",
+			input:    "\x1b[1;31ma\x1b[0m\x1b[1;32mb\x1b[0m",
+			expected: "\x1b[1;31ma\x1b[32mb\x1b[m",
+		},
+		{
+			name:     "bold and faint are turned off together",
+			input:    "\x1b[1;2;3ma\x1b[0m\x1b[2;3mb",
+			expected: "\x1b[1;2;3ma\x1b[22;2mb\x1b[m",
+		},
+		{
+			name:     "reset when shorter",
+			input:    "\x1b[1;3;4;7ma\x1b[0m\x1b[31mb",
+			expected: "\x1b[1;3;4;7ma\x1b[0;31mb\x1b[m",
+		},
+		{
+			name:     "sequences without text are dropped",
+			input:    "\x1b[31m\x1b[0mfoo\x1b[1m\x1b[0m",
+			expected: "foo",
+		},
+		{
+			name:     "extended colors",
+			input:    "\x1b[38;2;1;2;3;48;5;4ma\x1b[0m\x1b[38;2;1;2;3mb\x1b[0m",
+			expected: "\x1b[38;2;1;2;3;48;5;4ma\x1b[49mb\x1b[m",
+		},
+		{
+			name:     "unknown parameters are passed through",
+			input:    "\x1b[31ma\x1b[0m\x1b[31;11mb\x1b[1mc\x1b[0md",
+			expected: "\x1b[31ma\x1b[m\x1b[31;11mb\x1b[1mc\x1b[0md",
+		},
+		{
+			name:     "other sequences",
+			input:    "\x1b[31m\x1b]8;;https://charm.sh\x07a\x1b]8;;\x07\x1b[0m",
+			expected: "\x1b[31m\x1b]8;;https://charm.sh\x07a\x1b]8;;\x07\x1b[m",
+		},
+		{
+			name:     "unterminated style is reset",
+			input:    "a\x1b[4mb",
+			expected: "a\x1b[4mb\x1b[m",
+		},
+		{
+			name:     "style carried over to the next line",
+			input:    "\x1b[31mfoo\nbar\x1b[0m",
+			expected: "\x1b[31mfoo\x1b[m\n\x1b[31mbar\x1b[m",
+		},
+		{
+			name:     "unknown parameters carried over to the next line",
+			input:    "\x1b[11ma\x1b[1m\nb\x1b[0mc",
+			expected: "\x1b[11ma\x1b[1m\x1b[m\n\x1b[11m\x1b[1mb\x1b[0mc",
+		},
+		{
+			name:     "whitespace runs",
+			input:    "\x1b[41m \x1b[0m\x1b[41m \x1b[0m\x1b[41mab\x1b[0m",
+			expected: "\x1b[41m  ab\x1b[m",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := MinimizeSGR(tc.input)
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+					formatEscapes(tc.expected), formatEscapes(res))
+			}
+			if a, b := screen(tc.input), screen(res); a != b {
+				t.Errorf("rendering changed:\n%s\n%s", a, b)
+			}
+		})
+	}
+}
+
+func TestRendererMinimalSGR(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.TrueColor)
+	r.SetHasDarkBackground(true)
+
+	style := r.NewStyle().
+		Foreground(Color("#ff0000")).
+		Background(Color("#0000ff")).
+		Bold(true).
+		Padding(1, 2).
+		Border(NormalBorder()).
+		BorderForeground(Color("#00ff00"))
+	input := "Hello\nWorld"
+
+	full := style.Render(input)
+	r.SetMinimalSGR(true)
+	minimal := style.Render(input)
+
+	if len(minimal) >= len(full) {
+		t.Errorf("expected minimal output to be shorter: %d >= %d", len(minimal), len(full))
+	}
+	if a, b := screen(full), screen(minimal); a != b {
+		t.Errorf("rendering changed:\n%s\n%s", a, b)
+	}
+}
+
+func TestMinimalSGRComposition(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.ANSI)
+	r.SetMinimalSGR(true)
+
+	block := r.NewStyle().Foreground(Color("1")).Render("aa\nbb")
+	if expected := "\x1b[31maa\x1b[m\n\x1b[31mbb\x1b[m"; block != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(block))
+	}
+
+	// The unstyled block joined to the right must stay unstyled.
+	joined := JoinHorizontal(Top, block, "X\nY")
+	for _, cell := range []string{"X|", "Y|"} {
+		if !strings.Contains(screen(joined), cell) {
+			t.Errorf("expected %q to be unstyled in %s", cell[:1], screen(joined))
+		}
+	}
+
+	// Placing a minimized block keeps its lines intact.
+	placed := r.PlaceHorizontal(4, Right, block)
+	if a, b := screen("  "+block[:strings.Index(block, "\n")]), screen(placed[:strings.Index(placed, "\n")]); a != b {
+		t.Errorf("placing changed rendering:\n%s\n%s", a, b)
+	}
+}
+
+// screen describes how s renders: every cell with the SGR state it's drawn
+// with, and the background color at every line break. Sequences with unknown
+// parameters are kept in the state as they are.
+func screen(s string) string {
+	var (
+		b       strings.Builder
+		p       pen
+		unknown string
+		state   byte
+	)
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+		switch {
+		case isSGR(seq):
+			params := seq[2 : len(seq)-1]
+			if params == "" || params == "0" {
+				unknown = ""
+			}
+			if !p.apply(params) {
+				unknown = formatEscapes(seq)
+			}
+		case width > 0:
+			b.WriteString(seq + unknown + strings.Join(p.params(), ";") + "|")
+		case seq == "\n":
+			b.WriteString("\\n" + p.bg + "|")
+		default:
+			b.WriteString(formatEscapes(seq))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package lipgloss
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlaceOverlay places a string or text block on top of a background string
+// or text block at the given cell coordinates, where x is the column and y is
+// the row. The background remains visible around the placed content.
+//
+// The foreground is treated as a rectangular block: short lines are padded to
+// the width of its widest line. If the foreground extends past the edges of
+// the background, the background is grown with whitespace to fit.
+//
+// Styling in the background is preserved on both sides of the overlay, even
+// when the overlay cuts through a styled run.
+//
+// Example:
+//
+//	dialog := dialogStyle.Render("Are you sure?")
+//	view := lipgloss.PlaceOverlay(10, 4, dialog, app.View(),
+//	    lipgloss.WithDimmedBackground(),
+//	)
+func PlaceOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
+	return DefaultRenderer().PlaceOverlay(x, y, fg, bg, opts...)
+}
+
+// PlaceOverlay places a string or text block on top of a background string
+// or text block at the given cell coordinates, where x is the column and y is
+// the row. The background remains visible around the placed content.
+//
+// The foreground is treated as a rectangular block: short lines are padded to
+// the width of its widest line. If the foreground extends past the edges of
+// the background, the background is grown with whitespace to fit.
+//
+// Styling in the background is preserved on both sides of the overlay, even
+// when the overlay cuts through a styled run.
+func (r *Renderer) PlaceOverlay(x, y int, fg, bg string, opts ...WhitespaceOption) string {
+	return r.finish(overlay(x, y, fg, bg, newWhitespace(r, opts...)))
+}
+
+// placeOverBackground places str at the given offsets within a width by
+// height block cut from the whitespace's background content.
+func placeOverBackground(width, height, x, y int, str string, ws *whitespace) string {
+	lines := strings.Split(ws.content, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i := range lines {
+		lines[i] = ws.re.truncate(lines[i], width)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+
+	// Pad the first line so the background spans the full width even when
+	// the content is narrower.
+	if w := ws.re.stringWidth(lines[0]); w < width {
+		lines[0] += ws.render(width - w)
+	}
+
+	return overlay(x, y, str, strings.Join(lines, "\n"), ws)
+}
+
+// overlay writes fg over bg at the given cell coordinates.
+func overlay(x, y int, fg, bg string, ws *whitespace) string {
+	x = max(0, x)
+	y = max(0, y)
+
+	fgLines, fgWidth := ws.re.getLines(fg)
+	bgLines, bgWidth := ws.re.getLines(bg)
+
+	width := max(bgWidth, x+fgWidth)
+	height := max(len(bgLines), y+len(fgLines))
+
+	var b strings.Builder
+	for i := 0; i < height; i++ {
+		var line string
+		if i < len(bgLines) {
+			line = bgLines[i]
+		}
+		if w := ws.re.stringWidth(line); w < width {
+			line += ws.render(width - w)
+		}
+		if ws.dim {
+			line = dimLine(line)
+		}
+
+		if i >= y && i < y+len(fgLines) {
+			line = overlayLine(ws.re, line, fgLines[i-y], x, fgWidth, width)
+		}
+
+		b.WriteString(line)
+		if i < height-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// overlayLine writes fg over bg starting at column x. The foreground is padded
+// to fgWidth cells, and bg is expected to be width cells wide.
+func overlayLine(r *Renderer, bg, fg string, x, fgWidth, width int) string {
+	var b strings.Builder
+
+	left := r.truncate(bg, x)
+	b.WriteString(left)
+	if strings.ContainsRune(left, ansi.ESC) {
+		b.WriteString(ansi.ResetStyle)
+	}
+
+	// A wide character may have been cut in half. Fill the remaining cell so
+	// the overlay lands on the right column.
+	if w := r.stringWidth(left); w < x {
+		b.WriteString(strings.Repeat(" ", x-w))
+	}
+
+	b.WriteString(fg)
+	if strings.ContainsRune(fg, ansi.ESC) {
+		b.WriteString(ansi.ResetStyle)
+	}
+	if w := r.stringWidth(fg); w < fgWidth {
+		b.WriteString(strings.Repeat(" ", fgWidth-w))
+	}
+
+	// TruncateLeft keeps the escape sequences it skips over, so the styling
+	// of the background resumes where the overlay ends.
+	cut := x + fgWidth
+	right := r.truncateLeft(bg, cut)
+	if r.stringWidth(right) > width-cut {
+		// A wide character straddles the right edge of the overlay. Drop it
+		// and fill its visible half with a space.
+		right = " " + r.truncateLeft(bg, cut+1)
+	}
+	b.WriteString(right)
+
+	return b.String()
+}
+
+// dimLine renders a line faint while keeping its existing styles. Faint is
+// re-applied after every SGR sequence so resets within the line don't cancel
+// it.
+func dimLine(str string) string {
+	const faint = "\x1b[2m"
+
+	var (
+		b     strings.Builder
+		state byte
+	)
+	b.WriteString(faint)
+	for len(str) > 0 {
+		seq, _, n, newState := ansi.DecodeSequence(str, state, nil)
+		b.WriteString(seq)
+		if ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m") {
+			b.WriteString(faint)
+		}
+		state = newState
+		str = str[n:]
+	}
+	b.WriteString(ansi.ResetStyle)
+
+	return b.String()
+}
+
+// placeOffset returns the leading gap when placing content of a given size
+// within a larger space. It matches the split used by PlaceHorizontal and
+// PlaceVertical.
+func placeOffset(gap int, pos Position) int {
+	switch pos { //nolint:exhaustive
+	case Left:
+		return 0
+	case Right:
+		return gap
+	default:
+		split := int(math.Round(float64(gap) * pos.value()))
+		return gap - split
+	}
+}
@@ -0,0 +1,124 @@
+package lipgloss
+
+import "testing"
+
+func TestPlaceOverlay(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     int
+		fg, bg   string
+		opts     []WhitespaceOption
+		expected string
+	}{
+		{
+			name:     "inside",
+			x:        1,
+			y:        1,
+			fg:       "ab\nc",
+			bg:       "......\n......\n......",
+			expected: "......\n.ab...\n.c ...",
+		},
+		{
+			name:     "grows background",
+			x:        4,
+			y:        1,
+			fg:       "abc",
+			bg:       "....\n....",
+			expected: "....   \n....abc",
+		},
+		{
+			name:     "below background",
+			x:        0,
+			y:        2,
+			fg:       "ab",
+			bg:       "..",
+			expected: "..\n  \nab",
+		},
+		{
+			name:     "styled background",
+			x:        3,
+			y:        0,
+			fg:       "X",
+			bg:       "\x1b[31mhello world\x1b[0m",
+			expected: "\x1b[31mhel\x1b[0m\x1b[mX\x1b[31mo world\x1b[0m",
+		},
+		{
+			name:     "styled foreground",
+			x:        1,
+			y:        0,
+			fg:       "\x1b[1mX\x1b[0m",
+			bg:       "abc",
+			expected: "a\x1b[1mX\x1b[0m\x1b[mc",
+		},
+		{
+			name:     "wide characters",
+			x:        1,
+			y:        0,
+			fg:       "XY",
+			bg:       "你好世界",
+			expected: " XY 世界",
+		},
+		{
+			name:     "dimmed",
+			x:        1,
+			y:        0,
+			fg:       "X",
+			bg:       "a\x1b[31mbc\x1b[0m",
+			opts:     []WhitespaceOption{WithDimmedBackground()},
+			expected: "\x1b[2ma\x1b[31m\x1b[2m\x1b[0m\x1b[2m\x1b[m\x1b[mX\x1b[2m\x1b[31m\x1b[2mc\x1b[0m\x1b[2m\x1b[m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := PlaceOverlay(tc.x, tc.y, tc.fg, tc.bg, tc.opts...)
+			if res != tc.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestPlaceWithBackgroundContent(t *testing.T) {
+	bg := "abcde\nfghij\nklmno"
+
+	tests := []struct {
+		name     string
+		result   string
+		expected string
+	}{
+		{
+			"place",
+			Place(5, 3, Center, Center, "X", WithBackgroundContent(bg)),
+			"abcde\nfgXij\nklmno",
+		},
+		{
+			"place cuts background",
+			Place(3, 2, Right, Bottom, "X", WithBackgroundContent(bg)),
+			"abc\nfgX",
+		},
+		{
+			"place pads background",
+			Place(6, 4, Left, Top, "X", WithBackgroundContent(bg)),
+			"Xbcde \nfghij \nklmno \n      ",
+		},
+		{
+			"horizontal",
+			PlaceHorizontal(5, Right, "X\nY", WithBackgroundContent(bg)),
+			"abcdX\nfghiY",
+		},
+		{
+			"vertical",
+			PlaceVertical(3, Bottom, "XY", WithBackgroundContent(bg)),
+			"ab\nfg\nXY",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.result != tc.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tc.expected, tc.result)
+			}
+		})
+	}
+}
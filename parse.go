@@ -0,0 +1,331 @@
+package lipgloss
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrInvalidColor is returned, wrapped, by ParseColor when a color can't be
+// parsed. The wrapping error describes what's wrong with the value.
+var ErrInvalidColor = errors.New("invalid color")
+
+// ParseColor parses a color, returning an error if the value isn't valid.
+// Unlike Color, which renders invalid values as no color at all, this makes
+// typos in configuration files easy to catch. The following forms are
+// accepted:
+//
+//   - CSS named colors, which include most X11 names, such as "rebeccapurple"
+//     or "Light Sea Green", and the X11 greys "gray0" to "gray100"
+//   - hex values: "#rgb" and "#rrggbb", or "#rgba" and "#rrggbbaa" for
+//     semi-transparent colors (see [WithAlpha])
+//   - ANSI values: "ansi256(21)", or just "21"
+//   - rgb(r, g, b), where each channel is a number from 0 to 255 or a
+//     percentage
+//   - hsl(h, s%, l%), where the hue is in degrees
+//
+// Names and functions are case-insensitive, and function arguments may be
+// separated by commas or spaces. Hex values are returned as a Color in the
+// form "#rrggbb" or "#rrggbbaa", and ANSI values as an ANSIColor.
+func ParseColor(s string) (TerminalColor, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" {
+		return nil, fmt.Errorf("%w: empty value", ErrInvalidColor)
+	}
+
+	var (
+		c   TerminalColor
+		err error
+	)
+	switch {
+	case strings.HasPrefix(v, "#"):
+		c, err = parseHex(v)
+	case v[0] >= '0' && v[0] <= '9':
+		c, err = parseANSI(v)
+	case strings.HasSuffix(v, ")"):
+		c, err = parseColorFunc(v)
+	default:
+		c, err = parseColorName(v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidColor, s, err)
+	}
+	return c, nil
+}
+
+func parseHex(s string) (TerminalColor, error) {
+	hex := s[1:]
+	if len(hex) == 3 || len(hex) == 4 { //nolint:mnd
+		var b []byte
+		for i := range len(hex) {
+			b = append(b, hex[i], hex[i])
+		}
+		hex = string(b)
+	}
+	if len(hex) != 6 && len(hex) != 8 { //nolint:mnd
+		return nil, fmt.Errorf("hex values must have 3, 4, 6 or 8 digits, got %d", len(s)-1)
+	}
+	if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+		return nil, errors.New("hex values may only contain the digits 0-9 and a-f")
+	}
+	return Color("#" + hex), nil
+}
+
+func parseANSI(s string) (TerminalColor, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, errors.New("ANSI values must be whole numbers")
+	}
+	if n < 0 || n > 255 {
+		return nil, fmt.Errorf("ANSI values must be between 0 and 255, got %d", n)
+	}
+	return ANSIColor(n), nil
+}
+
+// parseColorFunc parses colors in functional notation, such as rgb(0, 0, 0).
+func parseColorFunc(s string) (TerminalColor, error) {
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return nil, errors.New("missing opening parenthesis")
+	}
+	name := strings.TrimSpace(s[:open])
+	args := strings.FieldsFunc(s[open+1:len(s)-1], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	switch name {
+	case "ansi256":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("ansi256() takes 1 argument, got %d", len(args))
+		}
+		return parseANSI(args[0])
+
+	case "rgb":
+		if len(args) != 3 { //nolint:mnd
+			return nil, fmt.Errorf("rgb() takes 3 arguments, got %d", len(args))
+		}
+		var c colorful.Color
+		for i, ch := range []*float64{&c.R, &c.G, &c.B} {
+			v, err := parseChannel(args[i], 255) //nolint:mnd
+			if err != nil {
+				return nil, fmt.Errorf("rgb() argument %d: %w", i+1, err)
+			}
+			*ch = v
+		}
+		return Color(c.Hex()), nil
+
+	case "hsl":
+		if len(args) != 3 { //nolint:mnd
+			return nil, fmt.Errorf("hsl() takes 3 arguments, got %d", len(args))
+		}
+		h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("hsl() argument 1: %q is not a number of degrees", args[0])
+		}
+		var sl [2]float64
+		for i := range sl {
+			if !strings.HasSuffix(args[i+1], "%") {
+				return nil, fmt.Errorf("hsl() argument %d: %q is not a percentage", i+2, args[i+1])
+			}
+			if sl[i], err = parseChannel(args[i+1], 100); err != nil { //nolint:mnd
+				return nil, fmt.Errorf("hsl() argument %d: %w", i+2, err)
+			}
+		}
+		h = math.Mod(math.Mod(h, 360)+360, 360) //nolint:mnd
+		return Color(colorful.Hsl(h, sl[0], sl[1]).Clamped().Hex()), nil
+
+	default:
+		return nil, fmt.Errorf("unknown color function %q", name)
+	}
+}
+
+// parseChannel parses a number between 0 and limit, or a percentage, and
+// returns it as a fraction between 0 and 1.
+func parseChannel(s string, limit float64) (float64, error) {
+	if p, ok := strings.CutSuffix(s, "%"); ok {
+		s, limit = p, 100 //nolint:mnd
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if v < 0 || v > limit {
+		return 0, fmt.Errorf("%s is out of range 0-%g", s, limit)
+	}
+	return v / limit, nil
+}
+
+func parseColorName(s string) (TerminalColor, error) {
+	name := strings.Join(strings.Fields(s), "")
+	if hex, ok := namedColors[name]; ok {
+		return Color(hex), nil
+	}
+
+	// X11 greys, from gray0 (black) to gray100 (white).
+	for _, prefix := range []string{"gray", "grey"} {
+		if n, ok := strings.CutPrefix(name, prefix); ok {
+			if v, err := strconv.Atoi(n); err == nil && v >= 0 && v <= 100 {
+				l := float64(v) / 100 //nolint:mnd
+				return Color(colorful.Color{R: l, G: l, B: l}.Hex()), nil
+			}
+		}
+	}
+
+	return nil, errors.New("unknown color name")
+}
+
+// namedColors are the CSS named colors.
+var namedColors = map[string]string{
+	"aliceblue":            "#f0f8ff",
+	"antiquewhite":         "#faebd7",
+	"aqua":                 "#00ffff",
+	"aquamarine":           "#7fffd4",
+	"azure":                "#f0ffff",
+	"beige":                "#f5f5dc",
+	"bisque":               "#ffe4c4",
+	"black":                "#000000",
+	"blanchedalmond":       "#ffebcd",
+	"blue":                 "#0000ff",
+	"blueviolet":           "#8a2be2",
+	"brown":                "#a52a2a",
+	"burlywood":            "#deb887",
+	"cadetblue":            "#5f9ea0",
+	"chartreuse":           "#7fff00",
+	"chocolate":            "#d2691e",
+	"coral":                "#ff7f50",
+	"cornflowerblue":       "#6495ed",
+	"cornsilk":             "#fff8dc",
+	"crimson":              "#dc143c",
+	"cyan":                 "#00ffff",
+	"darkblue":             "#00008b",
+	"darkcyan":             "#008b8b",
+	"darkgoldenrod":        "#b8860b",
+	"darkgray":             "#a9a9a9",
+	"darkgreen":            "#006400",
+	"darkgrey":             "#a9a9a9",
+	"darkkhaki":            "#bdb76b",
+	"darkmagenta":          "#8b008b",
+	"darkolivegreen":       "#556b2f",
+	"darkorange":           "#ff8c00",
+	"darkorchid":           "#9932cc",
+	"darkred":              "#8b0000",
+	"darksalmon":           "#e9967a",
+	"darkseagreen":         "#8fbc8f",
+	"darkslateblue":        "#483d8b",
+	"darkslategray":        "#2f4f4f",
+	"darkslategrey":        "#2f4f4f",
+	"darkturquoise":        "#00ced1",
+	"darkviolet":           "#9400d3",
+	"deeppink":             "#ff1493",
+	"deepskyblue":          "#00bfff",
+	"dimgray":              "#696969",
+	"dimgrey":              "#696969",
+	"dodgerblue":           "#1e90ff",
+	"firebrick":            "#b22222",
+	"floralwhite":          "#fffaf0",
+	"forestgreen":          "#228b22",
+	"fuchsia":              "#ff00ff",
+	"gainsboro":            "#dcdcdc",
+	"ghostwhite":           "#f8f8ff",
+	"gold":                 "#ffd700",
+	"goldenrod":            "#daa520",
+	"gray":                 "#808080",
+	"green":                "#008000",
+	"greenyellow":          "#adff2f",
+	"grey":                 "#808080",
+	"honeydew":             "#f0fff0",
+	"hotpink":              "#ff69b4",
+	"indianred":            "#cd5c5c",
+	"indigo":               "#4b0082",
+	"ivory":                "#fffff0",
+	"khaki":                "#f0e68c",
+	"lavender":             "#e6e6fa",
+	"lavenderblush":        "#fff0f5",
+	"lawngreen":            "#7cfc00",
+	"lemonchiffon":         "#fffacd",
+	"lightblue":            "#add8e6",
+	"lightcoral":           "#f08080",
+	"lightcyan":            "#e0ffff",
+	"lightgoldenrodyellow": "#fafad2",
+	"lightgray":            "#d3d3d3",
+	"lightgreen":           "#90ee90",
+	"lightgrey":            "#d3d3d3",
+	"lightpink":            "#ffb6c1",
+	"lightsalmon":          "#ffa07a",
+	"lightseagreen":        "#20b2aa",
+	"lightskyblue":         "#87cefa",
+	"lightslategray":       "#778899",
+	"lightslategrey":       "#778899",
+	"lightsteelblue":       "#b0c4de",
+	"lightyellow":          "#ffffe0",
+	"lime":                 "#00ff00",
+	"limegreen":            "#32cd32",
+	"linen":                "#faf0e6",
+	"magenta":              "#ff00ff",
+	"maroon":               "#800000",
+	"mediumaquamarine":     "#66cdaa",
+	"mediumblue":           "#0000cd",
+	"mediumorchid":         "#ba55d3",
+	"mediumpurple":         "#9370db",
+	"mediumseagreen":       "#3cb371",
+	"mediumslateblue":      "#7b68ee",
+	"mediumspringgreen":    "#00fa9a",
+	"mediumturquoise":      "#48d1cc",
+	"mediumvioletred":      "#c71585",
+	"midnightblue":         "#191970",
+	"mintcream":            "#f5fffa",
+	"mistyrose":            "#ffe4e1",
+	"moccasin":             "#ffe4b5",
+	"navajowhite":          "#ffdead",
+	"navy":                 "#000080",
+	"oldlace":              "#fdf5e6",
+	"olive":                "#808000",
+	"olivedrab":            "#6b8e23",
+	"orange":               "#ffa500",
+	"orangered":            "#ff4500",
+	"orchid":               "#da70d6",
+	"palegoldenrod":        "#eee8aa",
+	"palegreen":            "#98fb98",
+	"paleturquoise":        "#afeeee",
+	"palevioletred":        "#db7093",
+	"papayawhip":           "#ffefd5",
+	"peachpuff":            "#ffdab9",
+	"peru":                 "#cd853f",
+	"pink":                 "#ffc0cb",
+	"plum":                 "#dda0dd",
+	"powderblue":           "#b0e0e6",
+	"purple":               "#800080",
+	"rebeccapurple":        "#663399",
+	"red":                  "#ff0000",
+	"rosybrown":            "#bc8f8f",
+	"royalblue":            "#4169e1",
+	"saddlebrown":          "#8b4513",
+	"salmon":               "#fa8072",
+	"sandybrown":           "#f4a460",
+	"seagreen":             "#2e8b57",
+	"seashell":             "#fff5ee",
+	"sienna":               "#a0522d",
+	"silver":               "#c0c0c0",
+	"skyblue":              "#87ceeb",
+	"slateblue":            "#6a5acd",
+	"slategray":            "#708090",
+	"slategrey":            "#708090",
+	"snow":                 "#fffafa",
+	"springgreen":          "#00ff7f",
+	"steelblue":            "#4682b4",
+	"tan":                  "#d2b48c",
+	"teal":                 "#008080",
+	"thistle":              "#d8bfd8",
+	"tomato":               "#ff6347",
+	"turquoise":            "#40e0d0",
+	"violet":               "#ee82ee",
+	"wheat":                "#f5deb3",
+	"white":                "#ffffff",
+	"whitesmoke":           "#f5f5f5",
+	"yellow":               "#ffff00",
+	"yellowgreen":          "#9acd32",
+}
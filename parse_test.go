@@ -0,0 +1,77 @@
+package lipgloss
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TerminalColor
+	}{
+		{"#ff0000", Color("#ff0000")},
+		{"#F00", Color("#ff0000")},
+		{"#F008", Color("#ff000088")},
+		{"#ff000066", Color("#ff000066")},
+		{"  #5A56E0 ", Color("#5a56e0")},
+		{"21", ANSIColor(21)},
+		{"ansi256(196)", ANSIColor(196)},
+		{"rebeccapurple", Color("#663399")},
+		{"Light Sea Green", Color("#20b2aa")},
+		{"grey50", Color("#808080")},
+		{"gray100", Color("#ffffff")},
+		{"rgb(255, 0, 128)", Color("#ff0080")},
+		{"RGB(255 0 128)", Color("#ff0080")},
+		{"rgb(100%, 50%, 0%)", Color("#ff8000")},
+		{"hsl(120, 100%, 25%)", Color("#008000")},
+		{"hsl(-240deg 100% 50%)", Color("#00ff00")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			c, err := ParseColor(tc.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c != tc.expected {
+				t.Errorf("expected %#v, got %#v", tc.expected, c)
+			}
+		})
+	}
+}
+
+func TestParseColorErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", `invalid color: empty value`},
+		{"#ff000", `invalid color "#ff000": hex values must have 3, 4, 6 or 8 digits, got 5`},
+		{"#gg0000", `invalid color "#gg0000": hex values may only contain the digits 0-9 and a-f`},
+		{"256", `invalid color "256": ANSI values must be between 0 and 255, got 256`},
+		{"1.5", `invalid color "1.5": ANSI values must be whole numbers`},
+		{"rgb(1, 2)", `invalid color "rgb(1, 2)": rgb() takes 3 arguments, got 2`},
+		{"rgb(1, 2, 300)", `invalid color "rgb(1, 2, 300)": rgb() argument 3: 300 is out of range 0-255`},
+		{"rgb(1, x, 3)", `invalid color "rgb(1, x, 3)": rgb() argument 2: "x" is not a number`},
+		{"hsl(0, 50, 50%)", `invalid color "hsl(0, 50, 50%)": hsl() argument 2: "50" is not a percentage`},
+		{"cmyk(0, 0, 0, 0)", `invalid color "cmyk(0, 0, 0, 0)": unknown color function "cmyk"`},
+		{"rebeccapurpel", `invalid color "rebeccapurpel": unknown color name`},
+		{"gray101", `invalid color "gray101": unknown color name`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := ParseColor(tc.input)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !errors.Is(err, ErrInvalidColor) {
+				t.Errorf("expected error to wrap ErrInvalidColor")
+			}
+			if err.Error() != tc.expected {
+				t.Errorf("expected error %q, got %q", tc.expected, err.Error())
+			}
+		})
+	}
+}
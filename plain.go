@@ -0,0 +1,85 @@
+package lipgloss
+
+import (
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlainText returns whether the renderer renders plain text.
+func (r *Renderer) PlainText() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.plainText
+}
+
+// SetPlainText sets whether the renderer renders plain text. In plain text
+// mode every escape sequence is removed from the output of Style.Render and
+// the placement functions, including escape sequences in the strings passed
+// to them, while widths, padding and alignment stay exactly the same. This is
+// useful when the output is written to files, emails or logs.
+//
+// Box drawing characters are kept; use SetASCIIBorders to replace them too.
+//
+// This function is thread-safe.
+func (r *Renderer) SetPlainText(v bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.plainText = v
+}
+
+// SetPlainText sets whether the default renderer renders plain text.
+//
+// This function is thread-safe.
+func SetPlainText(v bool) {
+	DefaultRenderer().SetPlainText(v)
+}
+
+// ASCIIBorders returns whether the renderer draws borders with ASCII
+// characters.
+func (r *Renderer) ASCIIBorders() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.asciiBorders
+}
+
+// SetASCIIBorders sets whether the renderer draws borders with ASCII
+// characters. When enabled, the parts of a border that aren't ASCII are
+// replaced with their ASCIIBorder counterparts, whatever the terminal's
+// capabilities.
+//
+// This function is thread-safe.
+func (r *Renderer) SetASCIIBorders(v bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.asciiBorders = v
+}
+
+// SetASCIIBorders sets whether the default renderer draws borders with ASCII
+// characters.
+//
+// This function is thread-safe.
+func SetASCIIBorders(v bool) {
+	DefaultRenderer().SetASCIIBorders(v)
+}
+
+// plain removes escape sequences from str if the renderer renders plain
+// text.
+func (r *Renderer) plain(str string) string {
+	if !r.PlainText() {
+		return str
+	}
+	return ansi.Strip(str)
+}
+
+// finish applies the renderer's output settings to str: it removes escape
+// sequences in plain text mode, and minimizes SGR sequences otherwise if
+// asked to.
+func (r *Renderer) finish(str string) string {
+	switch {
+	case r.PlainText():
+		return ansi.Strip(str)
+	case r.MinimalSGR():
+		return MinimizeSGR(str)
+	default:
+		return str
+	}
+}
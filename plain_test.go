@@ -0,0 +1,96 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+func TestPlainText(t *testing.T) {
+	newRenderer := func(plain bool) *Renderer {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.TrueColor)
+		r.SetPlainText(plain)
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		render func(r *Renderer) string
+	}{
+		{"style", func(r *Renderer) string {
+			return r.NewStyle().
+				Bold(true).
+				Underline(true).
+				Foreground(Color("#ff0000")).
+				Background(Color("#0000ff")).
+				Padding(1, 2).
+				Width(20).
+				Align(Center).
+				Border(RoundedBorder()).
+				BorderForeground(Color("#00ff00")).
+				Render("hello\nworld")
+		}},
+		{"styled input", func(r *Renderer) string {
+			return r.NewStyle().Width(10).Align(Right).Render("\x1b[1mhi\x1b[0m")
+		}},
+		{"no props", func(r *Renderer) string {
+			return r.NewStyle().Render("\x1b[31mhi\x1b[0m")
+		}},
+		{"place", func(r *Renderer) string {
+			return r.Place(10, 3, Center, Center, "\x1b[31mhi\x1b[0m",
+				WithWhitespaceChars("."), WithWhitespaceForeground(Color("#ff0000")))
+		}},
+		{"place horizontal over background", func(r *Renderer) string {
+			return r.PlaceHorizontal(6, Center, "\x1b[31mhi\x1b[0m",
+				WithBackgroundContent("\x1b[44mabcdef\x1b[0m"))
+		}},
+		{"place vertical over background", func(r *Renderer) string {
+			return r.PlaceVertical(3, Bottom, "\x1b[31mhi\x1b[0m",
+				WithBackgroundContent("\x1b[44mab\ncd\nef\x1b[0m"))
+		}},
+		{"overlay", func(r *Renderer) string {
+			return r.PlaceOverlay(1, 0, "\x1b[31mx\x1b[0m", "abc", WithDimmedBackground())
+		}},
+		{"ranges", func(r *Renderer) string {
+			return StyleRanges("\x1b[1mhello\x1b[0m world", NewRange(2, 8, r.NewStyle().Reverse(true)))
+		}},
+		{"graphemes", func(r *Renderer) string {
+			return StyleGraphemes("hello", []int{1, 3}, r.NewStyle().Underline(true), r.NewStyle())
+		}},
+		{"highlight", func(r *Renderer) string {
+			return HighlightSubstring("hello world", "o", r.NewStyle().Foreground(Color("#ff0000")))
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			styled := tc.render(newRenderer(false))
+			plain := tc.render(newRenderer(true))
+
+			if plain != ansi.Strip(styled) {
+				t.Errorf("expected layout to be preserved:\n%q\ngot:\n%q", ansi.Strip(styled), plain)
+			}
+			if plain != ansi.Strip(plain) {
+				t.Errorf("expected no escape sequences, got %q", plain)
+			}
+		})
+	}
+}
+
+func TestASCIIBorders(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetColorProfile(termenv.Ascii)
+	r.SetASCIIBorders(true)
+
+	res := r.NewStyle().Border(DoubleBorder()).Render("hi")
+	if expected := "+--+\n|hi|\n+--+"; res != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, res)
+	}
+
+	res = r.NewStyle().Border(HiddenBorder()).Render("hi")
+	if expected := "    \n hi \n    "; res != expected {
+		t.Errorf("expected ASCII borders to be kept:\n%q\ngot:\n%q", expected, res)
+	}
+}
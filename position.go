@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/charmbracelet/x/ansi"
 )
 
 // Position represents a position along a horizontal or vertical axis. It's in
@@ -34,12 +32,21 @@ const (
 // Place places a string or text block vertically in an unstyled box of a given
 // width or height.
 func Place(width, height int, hPos, vPos Position, str string, opts ...WhitespaceOption) string {
-	return renderer.Place(width, height, hPos, vPos, str, opts...)
+	return DefaultRenderer().Place(width, height, hPos, vPos, str, opts...)
 }
 
 // Place places a string or text block vertically in an unstyled box of a given
 // width or height.
 func (r *Renderer) Place(width, height int, hPos, vPos Position, str string, opts ...WhitespaceOption) string {
+	if ws := newWhitespace(r, opts...); ws.content != "" {
+		_, contentWidth := r.getLines(str)
+		contentHeight := Height(str)
+		width = max(width, contentWidth)
+		height = max(height, contentHeight)
+		x := placeOffset(width-contentWidth, hPos)
+		y := placeOffset(height-contentHeight, vPos)
+		return r.finish(placeOverBackground(width, height, x, y, str, ws))
+	}
 	return r.PlaceVertical(height, vPos, r.PlaceHorizontal(width, hPos, str, opts...), opts...)
 }
 
@@ -47,14 +54,15 @@ func (r *Renderer) Place(width, height int, hPos, vPos Position, str string, opt
 // block of a given width. If the given width is shorter than the max width of
 // the string (measured by its longest line) this will be a noop.
 func PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOption) string {
-	return renderer.PlaceHorizontal(width, pos, str, opts...)
+	return DefaultRenderer().PlaceHorizontal(width, pos, str, opts...)
 }
 
 // PlaceHorizontal places a string or text block horizontally in an unstyled
 // block of a given width. If the given width is shorter than the max width of
 // the string (measured by its longest line) this will be a noöp.
 func (r *Renderer) PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOption) string {
-	lines, contentWidth := getLines(str)
+	str = r.plain(str)
+	lines, contentWidth := r.getLines(str)
 	gap := width - contentWidth
 
 	if gap <= 0 {
@@ -62,11 +70,14 @@ func (r *Renderer) PlaceHorizontal(width int, pos Position, str string, opts ...
 	}
 
 	ws := newWhitespace(r, opts...)
+	if ws.content != "" {
+		return r.finish(placeOverBackground(width, len(lines), placeOffset(gap, pos), 0, str, ws))
+	}
 
 	var b strings.Builder
 	for i, l := range lines {
 		// Is this line shorter than the longest line?
-		short := max(0, contentWidth-ansi.StringWidth(l))
+		short := max(0, contentWidth-r.stringWidth(l))
 
 		switch pos { //nolint:exhaustive
 		case Left:
@@ -101,13 +112,14 @@ func (r *Renderer) PlaceHorizontal(width int, pos Position, str string, opts ...
 // of a given height. If the given height is shorter than the height of the
 // string (measured by its newlines) then this will be a noop.
 func PlaceVertical(height int, pos Position, str string, opts ...WhitespaceOption) string {
-	return renderer.PlaceVertical(height, pos, str, opts...)
+	return DefaultRenderer().PlaceVertical(height, pos, str, opts...)
 }
 
 // PlaceVertical places a string or text block vertically in an unstyled block
 // of a given height. If the given height is shorter than the height of the
 // string (measured by its newlines) then this will be a noöp.
 func (r *Renderer) PlaceVertical(height int, pos Position, str string, opts ...WhitespaceOption) string {
+	str = r.plain(str)
 	contentHeight := strings.Count(str, "\n") + 1
 	gap := height - contentHeight
 
@@ -117,7 +129,11 @@ func (r *Renderer) PlaceVertical(height int, pos Position, str string, opts ...W
 
 	ws := newWhitespace(r, opts...)
 
-	_, width := getLines(str)
+	_, width := r.getLines(str)
+	if ws.content != "" {
+		return r.finish(placeOverBackground(width, height, 0, placeOffset(gap, pos), str, ws))
+	}
+
 	emptyLine := ws.render(width)
 	b := strings.Builder{}
 
@@ -0,0 +1,261 @@
+package lipgloss
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// ErrQueryTimeout is returned when the terminal doesn't finish answering a
+// query in time.
+var ErrQueryTimeout = errors.New("timed out waiting for the terminal")
+
+// TerminalColors are the colors a terminal reports using. Colors the terminal
+// didn't report are nil.
+type TerminalColors struct {
+	Foreground color.Color
+	Background color.Color
+	Palette    [16]color.Color
+}
+
+// QueryTerminalColors asks the terminal for its 16 color palette (OSC 4) and
+// its default foreground and background colors (OSC 10 and 11). The queries
+// are written to out, and the responses read from in, which makes it
+// possible to query terminals over SSH, or fake ones in tests.
+//
+// The queries end with a device attributes request, which all terminals
+// answer, so terminals that don't support some of the queries don't cause a
+// wait. Otherwise, if the terminal doesn't finish answering within the
+// timeout, the colors read so far are returned along with ErrQueryTimeout.
+//
+// Input read from in that isn't part of the responses, such as keys pressed
+// while the query was running, is returned as unread, in order, so that it
+// can be handled by the caller.
+//
+// The terminal should be in raw mode, so that the responses aren't echoed or
+// held back until a newline. Note that reading from in continues in the
+// background until a pending Read returns.
+func QueryTerminalColors(in io.Reader, out io.Writer, timeout time.Duration) (tc TerminalColors, unread []byte, err error) {
+	var q strings.Builder
+	for i := range 16 {
+		fmt.Fprintf(&q, "\x1b]4;%d;?\x07", i)
+	}
+	q.WriteString(ansi.RequestForegroundColor)
+	q.WriteString(ansi.RequestBackgroundColor)
+	q.WriteString(ansi.RequestPrimaryDeviceAttributes)
+	if _, err := io.WriteString(out, q.String()); err != nil {
+		return TerminalColors{}, nil, err
+	}
+
+	var (
+		chunks = make(chan []byte)
+		errs   = make(chan error, 1)
+		done   = make(chan struct{})
+	)
+	defer close(done)
+	go func() {
+		buf := make([]byte, 256) //nolint:mnd
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				select {
+				case chunks <- bytes.Clone(buf[:n]):
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	var (
+		pending []byte
+		timer   = time.NewTimer(timeout)
+	)
+	defer timer.Stop()
+	for {
+		select {
+		case b := <-chunks:
+			var (
+				other    []byte
+				finished bool
+			)
+			other, pending, finished = tc.parseResponses(append(pending, b...))
+			unread = append(unread, other...)
+			if finished {
+				return tc, unread, nil
+			}
+		case err := <-errs:
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return tc, append(unread, pending...), err
+		case <-timer.C:
+			return tc, append(unread, pending...), ErrQueryTimeout
+		}
+	}
+}
+
+// parseResponses parses the responses in buf. It returns the bytes of buf
+// that aren't part of a response, a sequence at the end of buf that may be
+// completed by the next read, and whether the device attributes response,
+// which ends the query, was found. Once it's found, the whole buffer is
+// parsed and nothing is left pending.
+func (tc *TerminalColors) parseResponses(buf []byte) (other, pending []byte, finished bool) {
+	for len(buf) > 0 {
+		i := bytes.IndexByte(buf, ansi.ESC)
+		if i < 0 {
+			other = append(other, buf...)
+			break
+		}
+		other = append(other, buf[:i]...)
+		buf = buf[i:]
+
+		end := -1
+		switch {
+		case len(buf) < 2: //nolint:mnd
+		case buf[1] == ']':
+			n := 1
+			end = bytes.IndexByte(buf, ansi.BEL)
+			if st := bytes.Index(buf, []byte("\x1b\\")); st > 0 && (end < 0 || st < end) {
+				end, n = st, 2 //nolint:mnd
+			}
+			if end < 0 {
+				break
+			}
+			if !tc.parseOSC(string(buf[2:end])) {
+				other = append(other, buf[:end+n]...)
+			}
+			end += n
+		case buf[1] == '[':
+			end = bytes.IndexFunc(buf[2:], func(r rune) bool {
+				return r >= 0x40 && r <= 0x7e
+			})
+			if end < 0 {
+				break
+			}
+			end += 2
+			if buf[end] == 'c' && buf[2] == '?' {
+				finished = true
+			} else {
+				other = append(other, buf[:end+1]...)
+			}
+			end++
+		default:
+			other = append(other, buf[0])
+			end = 1
+		}
+
+		if end < 0 {
+			// An incomplete sequence waits for the next read, unless
+			// nothing more will be read.
+			if finished {
+				other = append(other, buf...)
+			} else {
+				pending = buf
+			}
+			break
+		}
+		buf = buf[end:]
+	}
+	return other, pending, finished
+}
+
+// parseOSC parses the body of an OSC 4, 10 or 11 response, and reports
+// whether it was one.
+func (tc *TerminalColors) parseOSC(s string) bool {
+	cmd, rest, _ := strings.Cut(s, ";")
+	switch cmd {
+	case "4":
+		idx, value, _ := strings.Cut(rest, ";")
+		if i, err := strconv.Atoi(idx); err == nil && i >= 0 && i < len(tc.Palette) {
+			tc.Palette[i] = ansi.XParseColor(value)
+		}
+	case "10":
+		tc.Foreground = ansi.XParseColor(rest)
+	case "11":
+		tc.Background = ansi.XParseColor(rest)
+	default:
+		return false
+	}
+	return true
+}
+
+// QueryTerminalColors queries the terminal's colors like
+// [QueryTerminalColors] does, and keeps them on the renderer. The palette is
+// used for color downsampling and manipulation, and the background for
+// contrast and transparency, and to tell whether the background is dark.
+//
+// Colors read before a timeout are kept as well.
+func (r *Renderer) QueryTerminalColors(in io.Reader, out io.Writer, timeout time.Duration) (TerminalColors, []byte, error) {
+	tc, unread, err := QueryTerminalColors(in, out, timeout)
+	r.SetTerminalColors(tc)
+	return tc, unread, err
+}
+
+// TerminalColors returns the terminal colors kept on the renderer.
+func (r *Renderer) TerminalColors() TerminalColors {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.terminalColors
+}
+
+// SetTerminalColors sets the terminal colors on the renderer, as if they had
+// been queried. The colors of the palette replace the matching entries of the
+// renderer's palette; entries the terminal didn't report are left as they
+// are.
+//
+// This function is thread-safe.
+func (r *Renderer) SetTerminalColors(tc TerminalColors) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// Colors the terminal didn't report keep what was set before.
+	palette := append([]color.Color(nil), r.palette...)
+	for i, c := range tc.Palette {
+		if c == nil {
+			continue
+		}
+		if i >= len(palette) {
+			palette = append(palette, make([]color.Color, i+1-len(palette))...)
+		}
+		palette[i] = c
+	}
+	r.palette = palette
+	r.downsampleCache.Clear()
+
+	if tc.Background != nil {
+		if bg, ok := colorful.MakeColor(tc.Background); ok {
+			_, _, l := bg.Hsl()
+			r.hasDarkBackground = l < 0.5 //nolint:mnd
+			r.explicitBackgroundColor = true
+		}
+	}
+
+	r.terminalColors = tc
+}
+
+// backgroundColor returns the terminal's background color, if known, or
+// black or white, depending on whether the background is dark.
+func (r *Renderer) backgroundColor() colorful.Color {
+	if bg := r.TerminalColors().Background; bg != nil {
+		if c, ok := colorful.MakeColor(bg); ok {
+			return c
+		}
+	}
+	if r.HasDarkBackground() {
+		return colorful.Color{}
+	}
+	return colorful.Color{R: 1, G: 1, B: 1}
+}
@@ -0,0 +1,174 @@
+package lipgloss
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// fakeTerminal answers queries with canned responses once it has read them.
+type fakeTerminal struct {
+	queries   bytes.Buffer
+	responses io.Reader
+}
+
+func (f *fakeTerminal) Write(p []byte) (int, error) {
+	return f.queries.Write(p)
+}
+
+func (f *fakeTerminal) Read(p []byte) (int, error) {
+	return f.responses.Read(p)
+}
+
+func TestQueryTerminalColors(t *testing.T) {
+	var responses strings.Builder
+	responses.WriteString("\x1b]4;0;rgb:2828/2a2a/3636\x07")
+	responses.WriteString("\x1b]4;1;rgb:ff/55/55\x1b\\")
+	responses.WriteString("\x1b]10;rgb:f8f8/f8f8/f2f2\x07")
+	responses.WriteString("\x1b]11;#282a36\x07")
+	responses.WriteString("\x1b[?62;22c")
+
+	term := &fakeTerminal{responses: iotest.OneByteReader(strings.NewReader(responses.String()))}
+	tc, unread, err := QueryTerminalColors(term, term, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unread) > 0 {
+		t.Errorf("expected no unread input, got %q", unread)
+	}
+
+	if q := term.queries.String(); !strings.HasPrefix(q, "\x1b]4;0;?\x07\x1b]4;1;?\x07") ||
+		!strings.HasSuffix(q, "\x1b]10;?\x07\x1b]11;?\x07\x1b[c") {
+		t.Errorf("unexpected queries %q", q)
+	}
+
+	expect := func(name string, c color.Color, r, g, b uint8) {
+		t.Helper()
+		if c == nil {
+			t.Errorf("%s: expected a color", name)
+			return
+		}
+		cr, cg, cb, _ := c.RGBA()
+		if uint8(cr>>8) != r || uint8(cg>>8) != g || uint8(cb>>8) != b {
+			t.Errorf("%s: expected %02x%02x%02x, got %02x%02x%02x", name, r, g, b, cr>>8, cg>>8, cb>>8)
+		}
+	}
+	expect("palette 0", tc.Palette[0], 0x28, 0x2a, 0x36)
+	expect("palette 1", tc.Palette[1], 0xff, 0x55, 0x55)
+	expect("foreground", tc.Foreground, 0xf8, 0xf8, 0xf2)
+	expect("background", tc.Background, 0x28, 0x2a, 0x36)
+	if tc.Palette[2] != nil {
+		t.Errorf("expected unreported colors to be nil")
+	}
+}
+
+func TestQueryTerminalColorsTimeout(t *testing.T) {
+	in, w := io.Pipe()
+	defer w.Close()                                      //nolint:errcheck
+	go w.Write([]byte("\x1b]11;rgb:ffff/ffff/ffff\x07")) //nolint:errcheck
+
+	tc, _, err := QueryTerminalColors(in, io.Discard, 50*time.Millisecond)
+	if !errors.Is(err, ErrQueryTimeout) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	if tc.Background == nil {
+		t.Errorf("expected colors read before the timeout to be returned")
+	}
+}
+
+func TestQueryTerminalColorsUnread(t *testing.T) {
+	tt := []struct {
+		name      string
+		responses string
+		unread    string
+	}{
+		{
+			name:      "after the device attributes",
+			responses: "\x1b]11;#ffffff\x07\x1b[?62c\x1b]10;#000000\x07q\x1b[A",
+			unread:    "q\x1b[A",
+		},
+		{
+			name:      "between responses",
+			responses: "a\x1b]11;#ffffff\x07\x1b[B\x1b]52;c;aGk=\x07b\x1b[?62c",
+			unread:    "a\x1b[B\x1b]52;c;aGk=\x07b",
+		},
+		{
+			name:      "incomplete sequence",
+			responses: "\x1b]11;#ffffff\x07\x1b[?62cx\x1b]4;1",
+			unread:    "x\x1b]4;1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// The whole input arrives in a single read.
+			term := &fakeTerminal{responses: strings.NewReader(tc.responses)}
+			colors, unread, err := QueryTerminalColors(term, term, time.Second)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if colors.Background == nil {
+				t.Errorf("expected the background to be parsed")
+			}
+			if string(unread) != tc.unread {
+				t.Errorf("expected unread input %q, got %q", tc.unread, unread)
+			}
+		})
+	}
+}
+
+func TestRendererQueryTerminalColors(t *testing.T) {
+	term := &fakeTerminal{responses: strings.NewReader(
+		"\x1b]4;4;rgb:0000/0000/ffff\x07\x1b]11;rgb:ffff/ffff/ffff\x07\x1b[?1c",
+	)}
+
+	r := NewRenderer(nil)
+	r.SetHasDarkBackground(true)
+	if _, _, err := r.QueryTerminalColors(term, term, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.HasDarkBackground() {
+		t.Errorf("expected a light background")
+	}
+	if res := r.Darken(ANSIColor(4), 0); res != Color("#0000ff") {
+		t.Errorf("expected the queried palette color, got %#v", res)
+	}
+	if res := r.Alpha(Color("#000000"), 0.5); res != Color("#808080") {
+		t.Errorf("expected blending with the queried background, got %#v", res)
+	}
+}
+
+func TestSetTerminalColorsKeepsPalette(t *testing.T) {
+	theme := color.RGBA{0x28, 0x2a, 0x36, 0xff}
+	reported := color.RGBA{0xff, 0x55, 0x55, 0xff}
+
+	r := NewRenderer(nil)
+	r.SetPalette([]color.Color{theme, theme, theme})
+
+	var tc TerminalColors
+	tc.Palette[1] = reported
+	r.SetTerminalColors(tc)
+
+	p := r.Palette()
+	if len(p) != 3 {
+		t.Fatalf("expected the palette to keep its 3 entries, got %d", len(p))
+	}
+	if p[0] != theme || p[2] != theme {
+		t.Errorf("expected unreported entries to be kept, got %v", p)
+	}
+	if p[1] != reported {
+		t.Errorf("expected the reported entry to be set, got %v", p[1])
+	}
+
+	tc.Palette[5] = reported
+	r.SetTerminalColors(tc)
+	if p := r.Palette(); len(p) != 6 || p[5] != reported || p[3] != nil {
+		t.Errorf("expected the palette to grow to the reported entry, got %v", p)
+	}
+}
@@ -1,41 +1,188 @@
 package lipgloss
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 )
 
 // StyleRanges allows to, given a string, style ranges of it differently.
-// The function will take into account existing styles.
-// Ranges should not overlap.
+// The function will take into account existing styles: styling already in the
+// string is kept, and the text attributes of each range are layered on top of
+// it.
+//
+// Ranges may be given in any order, and may overlap or nest. Where they
+// overlap, the styles are layered in the order the ranges were given: later
+// ranges override the properties of earlier ones, the way [Style.Inherit]
+// does.
+//
+// Range boundaries are cell positions in the string with escape sequences
+// removed, measured with the width method of the first range's renderer. If
+// that renderer renders plain text, the string is returned without any escape
+// sequences.
+//
+// Only the text attributes and colors of the range styles are applied: bold,
+// italic, underline, reverse, blink, faint, strikethrough, and the foreground
+// and background colors. Everything else, such as padding, margins, borders,
+// width, height, alignment, tab width and Transform, is ignored, so the text
+// keeps its layout. To lay out part of a string, render it separately with
+// [Style.Render].
 func StyleRanges(s string, ranges ...Range) string {
 	if len(ranges) == 0 {
 		return s
 	}
 
-	var buf strings.Builder
-	lastIdx := 0
-	stripped := ansi.Strip(s)
+	r := ranges[0].Style.getRenderer()
+	if r.PlainText() {
+		return r.plain(s)
+	}
+
+	var (
+		buf     strings.Builder
+		history strings.Builder // SGR sequences seen in s so far
+		layers  = make(map[string]string)
+		active  string // key of the ranges covering the current cell
+		col     int
+		state   byte
+	)
+
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+
+		if width == 0 {
+			buf.WriteString(seq)
+			if isSGR(seq) {
+				// Nothing before a reset needs replaying.
+				if seq == sgrReset || seq == ansi.ResetStyle {
+					history.Reset()
+				} else {
+					history.WriteString(seq)
+				}
+				// Keep the ranges on top of the string's own styling.
+				if active != "" {
+					buf.WriteString(layers[active])
+				}
+			}
+			continue
+		}
 
-	// Use Truncate and TruncateLeft to style match.MatchedIndexes without
-	// losing the original option style:
-	for _, rng := range ranges {
-		// Add the text before this match
-		if rng.Start > lastIdx {
-			buf.WriteString(ansi.Cut(s, lastIdx, rng.Start))
+		// A cell belongs to the ranges that contain its right edge, so wide
+		// characters are matched like ansi.Cut matches them.
+		col += r.stringWidth(seq)
+		key := coveringRanges(ranges, col)
+		if key != "" {
+			if _, ok := layers[key]; !ok {
+				layers[key] = layerRanges(ranges, key).sgr()
+			}
+			// Ranges that don't style anything are left alone.
+			if layers[key] == "" {
+				key = ""
+			}
+		}
+		if key != active {
+			if active != "" {
+				buf.WriteString(sgrReset)
+				buf.WriteString(history.String())
+			}
+			buf.WriteString(layers[key])
+			active = key
 		}
-		// Add the matched range with its highlight
-		buf.WriteString(rng.Style.Render(ansi.Cut(stripped, rng.Start, rng.End)))
-		lastIdx = rng.End
+
+		buf.WriteString(seq)
 	}
 
-	// Add any remaining text after the last match
-	buf.WriteString(ansi.TruncateLeft(s, lastIdx, ""))
+	if active != "" {
+		buf.WriteString(sgrReset)
+	}
 
 	return buf.String()
 }
 
+// coveringRanges returns a key identifying the ranges that contain the cell
+// ending at col, in the order they were given.
+func coveringRanges(ranges []Range, col int) string {
+	var key []byte
+	for i, rng := range ranges {
+		if col > rng.Start && col <= rng.End {
+			key = strconv.AppendInt(key, int64(i), 10)
+			key = append(key, ',')
+		}
+	}
+	return string(key)
+}
+
+// layerRanges combines the styles of the ranges identified by key, with later
+// ranges taking precedence.
+func layerRanges(ranges []Range, key string) Style {
+	var style Style
+	for _, idx := range strings.Split(strings.TrimSuffix(key, ","), ",") {
+		i, _ := strconv.Atoi(idx)
+		style = ranges[i].Style.Inherit(style)
+	}
+	return style
+}
+
+const sgrReset = termenv.CSI + termenv.ResetSeq + "m"
+
+// isSGR reports whether seq is an SGR (Select Graphic Rendition) sequence.
+func isSGR(seq string) bool {
+	return ansi.HasCsiPrefix(seq) && strings.HasSuffix(seq, "m")
+}
+
+// sgr returns the SGR sequence that turns on the text attributes and colors
+// of the style, leaving out attributes the terminal doesn't support. It
+// returns an empty string if the style has none, if the color profile is
+// Ascii, or if the renderer renders plain text.
+func (s Style) sgr() string {
+	r := s.getRenderer()
+	if r.ColorProfile() == termenv.Ascii || r.PlainText() {
+		return ""
+	}
+
+	var (
+		seqs []string
+		caps = r.Capabilities()
+	)
+	for _, a := range []struct {
+		key propKey
+		seq string
+		ok  bool
+	}{
+		{boldKey, termenv.BoldSeq, true},
+		{italicKey, termenv.ItalicSeq, caps.Italic},
+		{underlineKey, termenv.UnderlineSeq, true},
+		{reverseKey, termenv.ReverseSeq, true},
+		{blinkKey, termenv.BlinkSeq, true},
+		{faintKey, termenv.FaintSeq, true},
+	} {
+		if a.ok && s.getAsBool(a.key, false) {
+			seqs = append(seqs, a.seq)
+		}
+	}
+	if fg := s.getAsColor(foregroundKey); fg != noColor {
+		if seq := fg.color(r).Sequence(false); seq != "" {
+			seqs = append(seqs, seq)
+		}
+	}
+	if bg := s.getAsColor(backgroundKey); bg != noColor {
+		if seq := bg.color(r).Sequence(true); seq != "" {
+			seqs = append(seqs, seq)
+		}
+	}
+	if caps.Strikethrough && s.getAsBool(strikethroughKey, false) {
+		seqs = append(seqs, termenv.CrossOutSeq)
+	}
+
+	if len(seqs) == 0 {
+		return ""
+	}
+	return termenv.CSI + strings.Join(seqs, ";") + "m"
+}
+
 // NewRange returns a range that can be used with [StyleRanges].
 func NewRange(start, end int, style Style) Range {
 	return Range{start, end, style}
@@ -1,6 +1,7 @@
 package lipgloss
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/muesli/termenv"
@@ -96,10 +97,78 @@ func TestStyleRanges(t *testing.T) {
 			},
 			expected: "\x1b[90m\ue615\x1b[39m \x1b[3m\x1b[32mDow\x1b[0m\x1b[90m\x1b[39m\x1b[3mnloads",
 		},
+		{
+			name:  "overlapping ranges",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(0, 7, NewStyle().Bold(true)),
+				NewRange(4, 11, NewStyle().Italic(true)),
+			},
+			expected: "\x1b[1mhell\x1b[0m\x1b[1;3mo w\x1b[0m\x1b[3morld\x1b[0m",
+		},
+		{
+			name:  "nested ranges",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(0, 11, NewStyle().Foreground(Color("1"))),
+				NewRange(6, 11, NewStyle().Bold(true)),
+			},
+			expected: "\x1b[31mhello \x1b[0m\x1b[1;31mworld\x1b[0m",
+		},
+		{
+			name:  "later ranges take precedence",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(0, 11, NewStyle().Foreground(Color("1"))),
+				NewRange(0, 5, NewStyle().Foreground(Color("2"))),
+			},
+			expected: "\x1b[32mhello\x1b[0m\x1b[31m world\x1b[0m",
+		},
+		{
+			name:  "unordered ranges",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(6, 11, NewStyle().Italic(true)),
+				NewRange(0, 5, NewStyle().Bold(true)),
+			},
+			expected: "\x1b[1mhello\x1b[0m \x1b[3mworld\x1b[0m",
+		},
+		{
+			name:  "overlapping ranges over existing ANSI",
+			input: "he\x1b[32mllo\x1b[0m world",
+			ranges: []Range{
+				NewRange(0, 5, NewStyle().Bold(true)),
+				NewRange(3, 7, NewStyle().Italic(true)),
+			},
+			expected: "\x1b[1mhe\x1b[32m\x1b[1ml\x1b[0m\x1b[32m\x1b[1;3mlo\x1b[0m\x1b[1;3m\x1b[0m\x1b[3m w\x1b[0morld",
+		},
+		{
+			name:  "layout properties are ignored",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(6, 11, NewStyle().
+					Bold(true).
+					Padding(1, 2).
+					Margin(1).
+					Border(NormalBorder()).
+					Width(20).
+					Align(Right).
+					Transform(strings.ToUpper)),
+			},
+			expected: "hello \x1b[1mworld\x1b[0m",
+		},
+		{
+			name:  "ranges without text attributes change nothing",
+			input: "hello world",
+			ranges: []Range{
+				NewRange(0, 5, NewStyle().PaddingLeft(2).Transform(strings.ToUpper)),
+			},
+			expected: "hello world",
+		},
 	}
 
 	for _, tt := range tests {
-		renderer.SetColorProfile(termenv.ANSI)
+		DefaultRenderer().SetColorProfile(termenv.ANSI)
 		t.Run(tt.name, func(t *testing.T) {
 			result := StyleRanges(tt.input, tt.ranges...)
 			if result != tt.expected {
@@ -1,16 +1,24 @@
 package lipgloss
 
 import (
+	"image/color"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/muesli/termenv"
 )
 
-// We're manually creating the struct here to avoid initializing the output and
-// query the terminal multiple times.
-var renderer = &Renderer{
-	output: termenv.DefaultOutput(),
+// defaultRenderer holds the default renderer. It's accessed atomically, so
+// that it can be replaced while styles are being rendered.
+var defaultRenderer atomic.Pointer[Renderer]
+
+func init() {
+	// We're manually creating the struct here to avoid initializing the output
+	// and query the terminal multiple times.
+	defaultRenderer.Store(&Renderer{
+		output: termenv.DefaultOutput(),
+	})
 }
 
 // Renderer is a lipgloss terminal renderer.
@@ -25,17 +33,47 @@ type Renderer struct {
 	getBackgroundColor      sync.Once
 	explicitBackgroundColor bool
 
+	widthMethod   WidthMethod
+	ambiguousWide bool
+
+	downsampling    Downsampling
+	palette         []color.Color
+	downsampleCache sync.Map
+
+	scheme string
+
+	terminalColors TerminalColors
+	colorBlindness ColorBlindness
+
+	environ              termenv.Environ
+	capabilities         Capabilities
+	getCapabilities      sync.Once
+	explicitCapabilities bool
+
+	plainText    bool
+	asciiBorders bool
+	minimalSGR   bool
+
 	mtx sync.RWMutex
 }
 
 // DefaultRenderer returns the default renderer.
+//
+// This function is thread-safe.
 func DefaultRenderer() *Renderer {
-	return renderer
+	return defaultRenderer.Load()
 }
 
-// SetDefaultRenderer sets the default global renderer.
+// SetDefaultRenderer sets the default global renderer. It applies to styles
+// created with NewStyle, including ones created before, but not to styles
+// created with Renderer.NewStyle or given a renderer with Style.Renderer.
+//
+// To use a different renderer for part of a program only, such as for each
+// client of a server, see WithRenderer.
+//
+// This function is thread-safe.
 func SetDefaultRenderer(r *Renderer) {
-	renderer = r
+	defaultRenderer.Store(r)
 }
 
 // NewRenderer creates a new Renderer.
@@ -80,7 +118,7 @@ func (r *Renderer) ColorProfile() termenv.Profile {
 
 // ColorProfile returns the detected termenv color profile.
 func ColorProfile() termenv.Profile {
-	return renderer.ColorProfile()
+	return DefaultRenderer().ColorProfile()
 }
 
 // SetColorProfile sets the color profile on the renderer. This function exists
@@ -124,12 +162,12 @@ func (r *Renderer) SetColorProfile(p termenv.Profile) {
 //
 // This function is thread-safe.
 func SetColorProfile(p termenv.Profile) {
-	renderer.SetColorProfile(p)
+	DefaultRenderer().SetColorProfile(p)
 }
 
 // HasDarkBackground returns whether or not the terminal has a dark background.
 func HasDarkBackground() bool {
-	return renderer.HasDarkBackground()
+	return DefaultRenderer().HasDarkBackground()
 }
 
 // HasDarkBackground returns whether or not the renderer will render to a dark
@@ -160,7 +198,7 @@ func (r *Renderer) HasDarkBackground() bool {
 //
 // This function is thread-safe.
 func SetHasDarkBackground(b bool) {
-	renderer.SetHasDarkBackground(b)
+	DefaultRenderer().SetHasDarkBackground(b)
 }
 
 // SetHasDarkBackground sets the background color detection value on the
@@ -2,6 +2,9 @@ package lipgloss
 
 import (
 	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/rivo/uniseg"
 )
 
 // StyleRunes apply a given style to runes at the given indices in the string.
@@ -41,3 +44,86 @@ func StyleRunes(str string, indices []int, matched, unmatched Style) string {
 
 	return out.String()
 }
+
+// IndexOption configures how [StyleGraphemes] interprets indices.
+type IndexOption func(*indexOptions)
+
+type indexOptions struct {
+	cells bool
+}
+
+// WithCellIndices makes [StyleGraphemes] treat indices as cell columns
+// instead of grapheme indices. A grapheme is matched if any of the cells it
+// occupies is.
+func WithCellIndices() IndexOption {
+	return func(o *indexOptions) {
+		o.cells = true
+	}
+}
+
+// StyleGraphemes applies a given style to the grapheme clusters at the given
+// indices in the string. Unlike [StyleRunes], emoji sequences and characters
+// with combining marks count as a single index, and ANSI escape sequences in
+// the string are skipped over and kept, so existing styling is preserved.
+//
+// The matched and unmatched styles are layered on top of the string's own
+// styling with [StyleRanges], so only their text attributes and colors are
+// applied. Indices out of bounds will be ignored.
+func StyleGraphemes(str string, indices []int, matched, unmatched Style, opts ...IndexOption) string {
+	var o indexOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r := matched.getRenderer()
+
+	m := make(map[int]struct{})
+	for _, i := range indices {
+		m[i] = struct{}{}
+	}
+
+	var (
+		ranges  []Range
+		start   int
+		col     int
+		idx     int
+		current bool
+	)
+
+	flush := func() {
+		if col == start {
+			return
+		}
+		style := unmatched
+		if current {
+			style = matched
+		}
+		ranges = append(ranges, NewRange(start, col, style))
+		start = col
+	}
+
+	// Escape sequences are stripped so that graphemes interrupted by them
+	// are still recognized.
+	g := uniseg.NewGraphemes(ansi.Strip(str))
+	for g.Next() {
+		width := r.stringWidth(g.Str())
+
+		var matches bool
+		if o.cells {
+			for c := col; c < col+width && !matches; c++ {
+				_, matches = m[c]
+			}
+		} else {
+			_, matches = m[idx]
+		}
+		idx++
+
+		if matches != current {
+			flush()
+			current = matches
+		}
+		col += width
+	}
+	flush()
+
+	return StyleRanges(str, ranges...)
+}
@@ -3,6 +3,8 @@ package lipgloss
 import (
 	"strings"
 	"testing"
+
+	"github.com/muesli/termenv"
 )
 
 func TestStyleRunes(t *testing.T) {
@@ -66,3 +68,80 @@ func TestStyleRunes(t *testing.T) {
 func formatEscapes(str string) string {
 	return strings.ReplaceAll(str, "\x1b", "\\x1b")
 }
+
+func TestStyleGraphemes(t *testing.T) {
+	DefaultRenderer().SetColorProfile(termenv.ANSI)
+	matchedStyle := NewStyle().Reverse(true)
+	unmatchedStyle := NewStyle()
+
+	tt := []struct {
+		name     string
+		input    string
+		indices  []int
+		opts     []IndexOption
+		expected string
+	}{
+		{
+			name:     "ascii",
+			input:    "hello",
+			indices:  []int{1, 3},
+			expected: "h\x1b[7me\x1b[0ml\x1b[7ml\x1b[0mo",
+		},
+		{
+			name:     "wide",
+			input:    "hello 你好",
+			indices:  []int{6, 7},
+			expected: "hello \x1b[7m你好\x1b[0m",
+		},
+		{
+			name:     "zwj emoji",
+			input:    "👩‍💻 code",
+			indices:  []int{0, 2},
+			expected: "\x1b[7m👩‍💻\x1b[0m \x1b[7mc\x1b[0mode",
+		},
+		{
+			name:     "combining marks",
+			input:    "cafe\u0301s",
+			indices:  []int{3, 4},
+			expected: "caf\x1b[7me\u0301s\x1b[0m",
+		},
+		{
+			name:     "existing styling",
+			input:    "\x1b[32mhello\x1b[0m world",
+			indices:  []int{1, 6},
+			expected: "\x1b[32mh\x1b[7me\x1b[0m\x1b[32mllo\x1b[0m \x1b[7mw\x1b[0morld",
+		},
+		{
+			name:     "cell indices",
+			input:    "你好 world",
+			indices:  []int{3, 5},
+			opts:     []IndexOption{WithCellIndices()},
+			expected: "你\x1b[7m好\x1b[0m \x1b[7mw\x1b[0morld",
+		},
+		{
+			name:     "out of bounds",
+			input:    "hello",
+			indices:  []int{-1, 4, 10},
+			expected: "hell\x1b[7mo\x1b[0m",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			res := StyleGraphemes(tc.input, tc.indices, matchedStyle, unmatchedStyle, tc.opts...)
+			if res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+					formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+
+	t.Run("unmatched style", func(t *testing.T) {
+		res := StyleGraphemes("abc", []int{1}, matchedStyle, NewStyle().Faint(true))
+		expected := "\x1b[2ma\x1b[0m\x1b[7mb\x1b[0m\x1b[2mc\x1b[0m"
+		if res != expected {
+			t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+				formatEscapes(expected), formatEscapes(res))
+		}
+	})
+}
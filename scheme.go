@@ -0,0 +1,111 @@
+package lipgloss
+
+import (
+	"github.com/muesli/termenv"
+)
+
+// Built-in color scheme names. Any other name can be used for custom schemes.
+const (
+	SchemeDark              = "dark"
+	SchemeLight             = "light"
+	SchemeHighContrastDark  = "high-contrast-dark"
+	SchemeHighContrastLight = "high-contrast-light"
+)
+
+// SchemeColor provides color options for any number of color schemes, keyed
+// by scheme name. The color for the renderer's current scheme is picked at
+// render time, so changing the scheme with SetScheme recolors existing
+// styles.
+//
+// If there's no color for the current scheme, the high contrast schemes fall
+// back to their regular counterparts, and any other scheme falls back to the
+// dark or light color, depending on the terminal's background. If there's
+// still no match, no color is used.
+//
+// Example usage:
+//
+//	color := lipgloss.SchemeColor{
+//		lipgloss.SchemeDark:             lipgloss.Color("#c0c0c0"),
+//		lipgloss.SchemeLight:            lipgloss.Color("#404040"),
+//		lipgloss.SchemeHighContrastDark: lipgloss.Color("#ffffff"),
+//		"solarized":                     lipgloss.Color("#839496"),
+//	}
+//
+// Note that, being a map, a SchemeColor can't be compared with ==.
+type SchemeColor map[string]TerminalColor
+
+func (sc SchemeColor) color(r *Renderer) termenv.Color {
+	return sc.resolve(r).color(r)
+}
+
+// RGBA returns the RGBA value of this color. This satisfies the Go Color
+// interface. Note that on error we return black with 100% opacity, or:
+//
+// Red: 0x0, Green: 0x0, Blue: 0x0, Alpha: 0xFFFF.
+//
+// Deprecated.
+func (sc SchemeColor) RGBA() (r, g, b, a uint32) {
+	return termenv.ConvertToRGB(sc.color(DefaultRenderer())).RGBA()
+}
+
+// resolve returns the color for the renderer's current scheme.
+func (sc SchemeColor) resolve(r *Renderer) TerminalColor {
+	scheme := r.Scheme()
+	if c, ok := sc[scheme]; ok && c != nil {
+		return c
+	}
+
+	var fallback string
+	switch scheme {
+	case SchemeHighContrastDark:
+		fallback = SchemeDark
+	case SchemeHighContrastLight:
+		fallback = SchemeLight
+	case SchemeDark, SchemeLight:
+	default:
+		fallback = SchemeLight
+		if r.HasDarkBackground() {
+			fallback = SchemeDark
+		}
+	}
+	if c, ok := sc[fallback]; ok && c != nil {
+		return c
+	}
+
+	return noColor
+}
+
+// Scheme returns the name of the renderer's color scheme. If no scheme has
+// been set, it's SchemeDark or SchemeLight, depending on the terminal's
+// background.
+func (r *Renderer) Scheme() string {
+	r.mtx.RLock()
+	scheme := r.scheme
+	r.mtx.RUnlock()
+
+	if scheme != "" {
+		return scheme
+	}
+	if r.HasDarkBackground() {
+		return SchemeDark
+	}
+	return SchemeLight
+}
+
+// SetScheme sets the renderer's color scheme, which determines the colors
+// picked from a SchemeColor. Set it to an empty string to pick the scheme
+// from the terminal's background.
+//
+// This function is thread-safe.
+func (r *Renderer) SetScheme(name string) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.scheme = name
+}
+
+// SetScheme sets the color scheme of the default renderer.
+//
+// This function is thread-safe.
+func SetScheme(name string) {
+	DefaultRenderer().SetScheme(name)
+}
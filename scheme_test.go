@@ -0,0 +1,76 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestSchemeColor(t *testing.T) {
+	c := SchemeColor{
+		SchemeDark:             Color("1"),
+		SchemeLight:            Color("2"),
+		SchemeHighContrastDark: Color("3"),
+		"solarized":            Color("4"),
+	}
+
+	tests := []struct {
+		name     string
+		scheme   string
+		dark     bool
+		expected string
+	}{
+		{"dark background", "", true, "\x1b[31mhi\x1b[0m"},
+		{"light background", "", false, "\x1b[32mhi\x1b[0m"},
+		{"explicit scheme", SchemeLight, true, "\x1b[32mhi\x1b[0m"},
+		{"high contrast", SchemeHighContrastDark, false, "\x1b[33mhi\x1b[0m"},
+		{"high contrast fallback", SchemeHighContrastLight, true, "\x1b[32mhi\x1b[0m"},
+		{"custom", "solarized", false, "\x1b[34mhi\x1b[0m"},
+		{"unknown custom falls back", "nord", true, "\x1b[31mhi\x1b[0m"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(nil)
+			r.SetColorProfile(termenv.ANSI)
+			r.SetHasDarkBackground(tc.dark)
+			r.SetScheme(tc.scheme)
+			if res := r.NewStyle().Foreground(c).Render("hi"); res != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+
+	t.Run("re-resolves", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.ANSI)
+		style := r.NewStyle().Foreground(c)
+
+		r.SetScheme(SchemeDark)
+		if res := style.Render("hi"); res != "\x1b[31mhi\x1b[0m" {
+			t.Errorf("unexpected output %q", res)
+		}
+		r.SetScheme(SchemeHighContrastDark)
+		if res := style.Render("hi"); res != "\x1b[33mhi\x1b[0m" {
+			t.Errorf("unexpected output %q", res)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.ANSI)
+		r.SetScheme(SchemeLight)
+		style := r.NewStyle().Foreground(SchemeColor{SchemeDark: Color("1")})
+		if res := style.Render("hi"); res != "hi" {
+			t.Errorf("unexpected output %q", res)
+		}
+	})
+
+	t.Run("manipulation", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetScheme(SchemeHighContrastDark)
+		if res := r.Darken(c, 0); res != Color("#808000") {
+			t.Errorf("expected #808000, got %#v", res)
+		}
+	})
+}
@@ -71,6 +71,8 @@ func (s *Style) set(key propKey, value interface{}) {
 		// TabWidth is the only property that may have a negative value (and
 		// that negative value can be no less than -1).
 		s.tabWidth = value.(int)
+	case tabModeKey:
+		s.tabMode = value.(TabMode)
 	case transformKey:
 		s.transform = value.(func(string) string)
 	default:
@@ -159,6 +161,8 @@ func (s *Style) setFrom(key propKey, i Style) {
 		s.set(maxHeightKey, i.maxHeight)
 	case tabWidthKey:
 		s.set(tabWidthKey, i.tabWidth)
+	case tabModeKey:
+		s.set(tabModeKey, i.tabMode)
 	case transformKey:
 		s.set(transformKey, i.transform)
 	default:
@@ -181,7 +185,7 @@ func addBorderFunc(a []interface{}, b BorderDecoration) []interface{} {
 		a = aa
 	}
 	i := posIndex(b.align)
-	a[i] = b.st
+	a[i] = b
 	return a
 }
 
@@ -359,6 +363,22 @@ func (s Style) PaddingBottom(i int) Style {
 	return s
 }
 
+// AutoForeground sets a rule for picking the foreground color that is the most
+// readable on the style's background, or on the terminal's background if the
+// style has none. Black or white is picked, or, when rendering with the 16
+// ANSI colors, the most readable entry of the palette. When enabled, it takes
+// precedence over the foreground color.
+//
+// This is handy when the background color is chosen by the user:
+//
+//	badge := lipgloss.NewStyle().
+//		Background(userColor).
+//		AutoForeground(true)
+func (s Style) AutoForeground(v bool) Style {
+	s.set(autoForegroundKey, v)
+	return s
+}
+
 // ColorWhitespace determines whether or not the background color should be
 // applied to the padding. This is true by default as it's more than likely the
 // desired and expected behavior, but it can be disabled for certain graphic
@@ -690,6 +710,26 @@ func (s Style) BorderDecoration(bd BorderDecoration) Style {
 	return s
 }
 
+// BorderTitle sets a title on the top border, aligned to the Left, Center or
+// Right. The title is padded with a space on each side, and ends with "…"
+// when it's shortened because the border is too narrow. Options change the
+// padding, ellipsis and priority, and frame the title with brackets:
+//
+//	lipgloss.NewStyle().
+//	    Border(lipgloss.NormalBorder()).
+//	    BorderTitle("Title", lipgloss.Left, lipgloss.WithTitleBrackets("┤", "├"))
+//
+// draws a top border like ┌┤ Title ├─────┐.
+func (s Style) BorderTitle(title string, pos Position, opts ...BorderTitleOption) Style {
+	return s.BorderDecoration(newBorderTitle(BorderTop, pos, title, opts...))
+}
+
+// BorderFooter sets a footer on the bottom border, aligned to the Left,
+// Center or Right. See BorderTitle for the options.
+func (s Style) BorderFooter(footer string, pos Position, opts ...BorderTitleOption) Style {
+	return s.BorderDecoration(newBorderTitle(BorderBottom, pos, footer, opts...))
+}
+
 // Inline makes rendering output one line and disables the rendering of
 // margins, padding and borders. This is useful when you need a style to apply
 // only to font rendering and don't want it to change any physical dimensions.
@@ -756,6 +796,27 @@ func (s Style) TabWidth(n int) Style {
 	return s
 }
 
+// TabMode sets how tabs are converted to spaces at render time. By default
+// each tab is replaced with a fixed number of spaces (see [Style.TabWidth]).
+// Use [TabStops] to expand tabs to the next tab stop, so tab-separated
+// columns line up, or [ElasticTabs] to align tab-separated cells across
+// consecutive lines.
+//
+// Tab stops are placed every TabWidth cells. The mode has no effect when tabs
+// are removed or not converted at all.
+//
+// Example:
+//
+//	s := lipgloss.NewStyle().TabMode(lipgloss.ElasticTabs)
+//	fmt.Println(s.Render("name\tsize\nREADME.md\t4 KB"))
+//
+//	// name        size
+//	// README.md   4 KB
+func (s Style) TabMode(m TabMode) Style {
+	s.set(tabModeKey, m)
+	return s
+}
+
 // UnderlineSpaces determines whether to underline spaces between words. By
 // default, this is true. Spaces can also be underlined without underlining the
 // text itself.
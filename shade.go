@@ -0,0 +1,201 @@
+package lipgloss
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Lighten returns the color with its lightness increased by amount, where
+// amount is a fraction between 0 and 1 of the full lightness range. Adaptive
+// colors are resolved against the renderer's background.
+//
+// Colors that can't be resolved, such as NoColor, are returned unchanged.
+func (r *Renderer) Lighten(c TerminalColor, amount float64) TerminalColor {
+	return r.adjustHSL(c, func(h, s, l float64) (float64, float64, float64) {
+		return h, s, l + amount
+	})
+}
+
+// Darken returns the color with its lightness decreased by amount, where
+// amount is a fraction between 0 and 1 of the full lightness range. Adaptive
+// colors are resolved against the renderer's background.
+//
+// Colors that can't be resolved, such as NoColor, are returned unchanged.
+func (r *Renderer) Darken(c TerminalColor, amount float64) TerminalColor {
+	return r.Lighten(c, -amount)
+}
+
+// Saturate returns the color with its saturation increased by amount, where
+// amount is a fraction between 0 and 1. A negative amount desaturates the
+// color. Adaptive colors are resolved against the renderer's background.
+//
+// Colors that can't be resolved, such as NoColor, are returned unchanged.
+func (r *Renderer) Saturate(c TerminalColor, amount float64) TerminalColor {
+	return r.adjustHSL(c, func(h, s, l float64) (float64, float64, float64) {
+		return h, s + amount, l
+	})
+}
+
+// Complement returns the color on the opposite side of the color wheel.
+// Adaptive colors are resolved against the renderer's background.
+//
+// Colors that can't be resolved, such as NoColor, are returned unchanged.
+func (r *Renderer) Complement(c TerminalColor) TerminalColor {
+	return r.adjustHSL(c, func(h, s, l float64) (float64, float64, float64) {
+		return math.Mod(h+180, 360), s, l //nolint:mnd
+	})
+}
+
+// Blend returns the color a fraction t of the way from a to b, where t is
+// between 0 and 1. Colors are blended in the CIE L*a*b* color space, so the
+// steps in between look evenly spaced. Adaptive colors are resolved against
+// the renderer's background.
+//
+// If either color can't be resolved, the other one is returned.
+func (r *Renderer) Blend(a, b TerminalColor, t float64) TerminalColor {
+	ca, okA := r.resolveColor(a)
+	cb, okB := r.resolveColor(b)
+	switch {
+	case !okA:
+		return b
+	case !okB:
+		return a
+	}
+	t = clamp(t, 0, 1)
+	return Color(ca.BlendLab(cb, t).Clamped().Hex())
+}
+
+// Alpha returns the opaque color that [WithAlpha] gives c when it's drawn
+// directly over the terminal's background, where alpha is between 0 (fully
+// transparent) and 1 (opaque). Unless the terminal's colors have been
+// queried, the background is taken to be black on dark backgrounds and white
+// on light ones.
+//
+// Use WithAlpha for colors given to a style, so that they're blended with
+// whatever they're drawn over when rendering. Use Alpha when an opaque color
+// is needed up front, such as to measure its contrast or to pass it to code
+// that doesn't render with lipgloss.
+//
+// Colors that can't be resolved, such as NoColor, are returned unchanged.
+func (r *Renderer) Alpha(c TerminalColor, alpha float64) TerminalColor {
+	return r.composite(WithAlpha(c, alpha), noColor)
+}
+
+// Lighten returns the color with its lightness increased by amount, resolving
+// adaptive colors against the default renderer. See [Renderer.Lighten].
+func Lighten(c TerminalColor, amount float64) TerminalColor {
+	return DefaultRenderer().Lighten(c, amount)
+}
+
+// Darken returns the color with its lightness decreased by amount, resolving
+// adaptive colors against the default renderer. See [Renderer.Darken].
+func Darken(c TerminalColor, amount float64) TerminalColor {
+	return DefaultRenderer().Darken(c, amount)
+}
+
+// Saturate returns the color with its saturation increased by amount,
+// resolving adaptive colors against the default renderer. See
+// [Renderer.Saturate].
+func Saturate(c TerminalColor, amount float64) TerminalColor {
+	return DefaultRenderer().Saturate(c, amount)
+}
+
+// Complement returns the color on the opposite side of the color wheel,
+// resolving adaptive colors against the default renderer. See
+// [Renderer.Complement].
+func Complement(c TerminalColor) TerminalColor {
+	return DefaultRenderer().Complement(c)
+}
+
+// Blend returns the color a fraction t of the way from a to b, resolving
+// adaptive colors against the default renderer. See [Renderer.Blend].
+func Blend(a, b TerminalColor, t float64) TerminalColor {
+	return DefaultRenderer().Blend(a, b, t)
+}
+
+// Alpha returns the opaque color that [WithAlpha] gives c when it's drawn
+// directly over the default renderer's background. See [Renderer.Alpha].
+func Alpha(c TerminalColor, alpha float64) TerminalColor {
+	return DefaultRenderer().Alpha(c, alpha)
+}
+
+// adjustHSL applies fn to the color in the HSL color space.
+func (r *Renderer) adjustHSL(c TerminalColor, fn func(h, s, l float64) (float64, float64, float64)) TerminalColor {
+	cf, ok := r.resolveColor(c)
+	if !ok {
+		return c
+	}
+	h, s, l := fn(cf.Hsl())
+	return Color(colorful.Hsl(h, clamp(s, 0, 1), clamp(l, 0, 1)).Clamped().Hex())
+}
+
+// resolveColor returns the full precision RGB value of a color, picking the
+// variant of adaptive colors that matches the renderer's background.
+// Unlike rendering, the color isn't degraded to the renderer's color profile.
+func (r *Renderer) resolveColor(c TerminalColor) (colorful.Color, bool) {
+	if _, _, ok := splitAlpha(c); ok {
+		c = r.composite(c, noColor)
+	}
+
+	var s string
+	switch c := c.(type) {
+	case Color:
+		s = string(c)
+	case ANSIColor:
+		s = strconv.FormatUint(uint64(c), 10)
+	case AdaptiveColor:
+		s = c.Light
+		if r.HasDarkBackground() {
+			s = c.Dark
+		}
+	case CompleteColor:
+		s = c.mostPrecise()
+	case CompleteAdaptiveColor:
+		s = c.Light.mostPrecise()
+		if r.HasDarkBackground() {
+			s = c.Dark.mostPrecise()
+		}
+	case SchemeColor:
+		return r.resolveColor(c.resolve(r))
+	default:
+		return colorful.Color{}, false
+	}
+
+	if _, _, ok := splitAlpha(Color(s)); ok {
+		return r.resolveColor(Color(s))
+	}
+	return r.parseHexOrANSI(s)
+}
+
+// parseHexOrANSI parses a color given as a hex value or an ANSI256 index.
+// ANSI colors take the renderer's palette into account.
+func (r *Renderer) parseHexOrANSI(s string) (colorful.Color, bool) {
+	if !strings.HasPrefix(s, "#") {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i > 255 {
+			return colorful.Color{}, false
+		}
+		return r.paletteColor(i), true
+	}
+	cf, err := colorful.Hex(s)
+	return cf, err == nil
+}
+
+// mostPrecise returns the most precise value set on the color.
+func (c CompleteColor) mostPrecise() string {
+	switch {
+	case c.TrueColor != "":
+		return c.TrueColor
+	case c.ANSI256 != "":
+		return c.ANSI256
+	default:
+		return c.ANSI
+	}
+}
+
+func clamp(v, low, high float64) float64 {
+	return math.Min(math.Max(v, low), high)
+}
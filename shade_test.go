@@ -0,0 +1,84 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestColorManipulation(t *testing.T) {
+	r := NewRenderer(nil)
+	r.SetHasDarkBackground(true)
+
+	tests := []struct {
+		name     string
+		fn       func() TerminalColor
+		expected TerminalColor
+	}{
+		{"lighten", func() TerminalColor { return r.Lighten(Color("#800000"), 0.25) }, Color("#ff0000")},
+		{"lighten clamps", func() TerminalColor { return r.Lighten(Color("#808080"), 2) }, Color("#ffffff")},
+		{"darken", func() TerminalColor { return r.Darken(Color("#ff0000"), 0.25) }, Color("#800000")},
+		{"darken ansi", func() TerminalColor { return r.Darken(ANSIColor(15), 1) }, Color("#000000")},
+		{"saturate", func() TerminalColor { return r.Saturate(Color("#806060"), 0.5) }, Color("#b82828")},
+		{"desaturate", func() TerminalColor { return r.Saturate(Color("#ff0000"), -1) }, Color("#808080")},
+		{"complement", func() TerminalColor { return r.Complement(Color("#ff0000")) }, Color("#00ffff")},
+		{"complement ansi256", func() TerminalColor { return r.Complement(Color("21")) }, Color("#ffff00")},
+		{"blend start", func() TerminalColor { return r.Blend(Color("#ff0000"), Color("#0000ff"), 0) }, Color("#ff0000")},
+		{"blend end", func() TerminalColor { return r.Blend(Color("#ff0000"), Color("#0000ff"), 1) }, Color("#0000ff")},
+		{"blend no color", func() TerminalColor { return r.Blend(NoColor{}, Color("#0000ff"), 0.5) }, Color("#0000ff")},
+		{"alpha dark", func() TerminalColor { return r.Alpha(Color("#ffffff"), 0.5) }, Color("#808080")},
+		{"alpha opaque", func() TerminalColor { return r.Alpha(Color("#123456"), 1) }, Color("#123456")},
+		{"alpha multiplies", func() TerminalColor { return r.Alpha(Color("#ffffff80"), 0.5) }, Color("#404040")},
+		{"alpha no color", func() TerminalColor { return r.Alpha(NoColor{}, 0.5) }, NoColor{}},
+		{"adaptive", func() TerminalColor {
+			return r.Lighten(AdaptiveColor{Light: "#ffffff", Dark: "#000000"}, 0.5)
+		}, Color("#808080")},
+		{"complete", func() TerminalColor {
+			return r.Complement(CompleteColor{TrueColor: "#ff0000", ANSI256: "21", ANSI: "4"})
+		}, Color("#00ffff")},
+		{"complete adaptive", func() TerminalColor {
+			return r.Darken(CompleteAdaptiveColor{
+				Light: CompleteColor{TrueColor: "#ffffff"},
+				Dark:  CompleteColor{ANSI: "9"},
+			}, 0)
+		}, Color("#ff0000")},
+		{"no color", func() TerminalColor { return r.Lighten(NoColor{}, 0.5) }, NoColor{}},
+		{"invalid", func() TerminalColor { return r.Lighten(Color("nope"), 0.5) }, Color("nope")},
+		{"out of range", func() TerminalColor { return r.Lighten(ANSIColor(300), 0.5) }, ANSIColor(300)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if res := tc.fn(); res != tc.expected {
+				t.Errorf("expected %#v, got %#v", tc.expected, res)
+			}
+		})
+	}
+
+	t.Run("alpha light", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetHasDarkBackground(false)
+		if res := r.Alpha(Color("#000000"), 0.5); res != Color("#808080") {
+			t.Errorf("expected #808080, got %#v", res)
+		}
+	})
+
+	t.Run("alpha matches WithAlpha", func(t *testing.T) {
+		r := NewRenderer(nil)
+		r.SetColorProfile(termenv.TrueColor)
+		r.SetHasDarkBackground(true)
+		c := Color("#3366cc")
+		a := r.NewStyle().Foreground(r.Alpha(c, 0.3)).Render("hi")
+		b := r.NewStyle().Foreground(WithAlpha(c, 0.3)).Render("hi")
+		if a != b {
+			t.Errorf("expected %q, got %q", b, a)
+		}
+	})
+
+	t.Run("blend midpoint", func(t *testing.T) {
+		res := r.Blend(Color("#000000"), Color("#ffffff"), 0.5)
+		if res == Color("#000000") || res == Color("#ffffff") {
+			t.Errorf("expected a color in between, got %#v", res)
+		}
+	})
+}
@@ -2,25 +2,17 @@ package lipgloss
 
 import (
 	"strings"
-
-	"github.com/charmbracelet/x/ansi"
 )
 
 // Width returns the cell width of characters in the string. ANSI sequences are
 // ignored and characters wider than one cell (such as Chinese characters and
-// emojis) are appropriately measured.
+// emojis) are appropriately measured, using the default renderer's width
+// method.
 //
 // You should use this instead of len(string) len([]rune(string) as neither
 // will give you accurate results.
 func Width(str string) (width int) {
-	for _, l := range strings.Split(str, "\n") {
-		w := ansi.StringWidth(l)
-		if w > width {
-			width = w
-		}
-	}
-
-	return width
+	return DefaultRenderer().Width(str)
 }
 
 // Height returns height of a string in cells. This is done simply by
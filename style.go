@@ -4,8 +4,6 @@ import (
 	"strings"
 	"unicode"
 
-	"github.com/charmbracelet/x/ansi"
-	"github.com/charmbracelet/x/cellbuf"
 	"github.com/muesli/termenv"
 )
 
@@ -27,6 +25,7 @@ const (
 	underlineSpacesKey
 	strikethroughSpacesKey
 	colorWhitespaceKey
+	autoForegroundKey
 
 	// Non-boolean props.
 	foregroundKey
@@ -80,6 +79,7 @@ const (
 	maxWidthKey
 	maxHeightKey
 	tabWidthKey
+	tabModeKey
 
 	transformKey
 )
@@ -106,8 +106,12 @@ func (p props) has(k propKey) bool {
 // Style{} primitive, it's recommended to use this function for creating styles
 // in case the underlying implementation changes. It takes an optional string
 // value to be set as the underlying string value for this style.
+//
+// The style isn't tied to a renderer: it renders with the default renderer at
+// the time it's rendered, or with the renderer carried by the context given to
+// Style.RenderContext. Use Renderer.NewStyle for a style bound to a renderer.
 func NewStyle() Style {
-	return renderer.NewStyle()
+	return Style{}
 }
 
 // NewStyle returns a new, empty Style. While it's syntactic sugar for the
@@ -167,6 +171,7 @@ type Style struct {
 	maxWidth  int
 	maxHeight int
 	tabWidth  int
+	tabMode   TabMode
 
 	transform func(string) string
 }
@@ -244,7 +249,7 @@ func (s Style) Inherit(i Style) Style {
 // Render applies the defined style formatting to a given string.
 func (s Style) Render(strs ...string) string {
 	if s.r == nil {
-		s.r = renderer
+		s.r = DefaultRenderer()
 	}
 	if s.value != "" {
 		strs = append([]string{s.value}, strs...)
@@ -254,20 +259,24 @@ func (s Style) Render(strs ...string) string {
 		str = joinString(strs...)
 
 		p            = s.r.ColorProfile()
+		caps         = s.r.Capabilities()
 		te           = p.String()
 		teSpace      = p.String()
 		teWhitespace = p.String()
 
 		bold          = s.getAsBool(boldKey, false)
-		italic        = s.getAsBool(italicKey, false)
+		italic        = s.getAsBool(italicKey, false) && caps.Italic
 		underline     = s.getAsBool(underlineKey, false)
-		strikethrough = s.getAsBool(strikethroughKey, false)
+		strikethrough = s.getAsBool(strikethroughKey, false) && caps.Strikethrough
 		reverse       = s.getAsBool(reverseKey, false)
 		blink         = s.getAsBool(blinkKey, false)
 		faint         = s.getAsBool(faintKey, false)
 
-		fg = s.getAsColor(foregroundKey)
-		bg = s.getAsColor(backgroundKey)
+		// Transparent colors are blended with the color underneath.
+		bg = s.r.composite(s.getAsColor(backgroundKey), s.getAsColor(marginBackgroundKey))
+		fg = s.r.composite(s.getAsColor(foregroundKey), bg)
+
+		autoForeground = s.getAsBool(autoForegroundKey, false)
 
 		width           = s.getAsInt(widthKey)
 		height          = s.getAsInt(heightKey)
@@ -285,7 +294,7 @@ func (s Style) Render(strs ...string) string {
 		maxHeight       = s.getAsInt(maxHeightKey)
 
 		underlineSpaces     = s.getAsBool(underlineSpacesKey, false) || (underline && s.getAsBool(underlineSpacesKey, true))
-		strikethroughSpaces = s.getAsBool(strikethroughSpacesKey, false) || (strikethrough && s.getAsBool(strikethroughSpacesKey, true))
+		strikethroughSpaces = caps.Strikethrough && (s.getAsBool(strikethroughSpacesKey, false) || (strikethrough && s.getAsBool(strikethroughSpacesKey, true)))
 
 		// Do we need to style whitespace (padding and space outside
 		// paragraphs) separately?
@@ -302,7 +311,7 @@ func (s Style) Render(strs ...string) string {
 	}
 
 	if s.props == 0 {
-		return s.maybeConvertTabs(str)
+		return s.r.finish(s.maybeConvertTabs(str))
 	}
 
 	// Enable support for ANSI on the legacy Windows cmd.exe console. This is a
@@ -329,6 +338,10 @@ func (s Style) Render(strs ...string) string {
 		te = te.Faint()
 	}
 
+	if autoForeground {
+		fg = s.r.readableForeground(bg)
+	}
+
 	if fg != noColor {
 		te = te.Foreground(fg.color(s.r))
 		if styleWhitespace {
@@ -376,7 +389,7 @@ func (s Style) Render(strs ...string) string {
 	// Word wrap
 	if !inline && width > 0 {
 		wrapAt := width - leftPadding - rightPadding
-		str = cellbuf.Wrap(str, wrapAt, "")
+		str = s.r.wrapStyled(str, wrapAt)
 	}
 
 	// Render core text
@@ -448,7 +461,7 @@ func (s Style) Render(strs ...string) string {
 			if colorWhitespace || styleWhitespace {
 				st = &teWhitespace
 			}
-			str = alignTextHorizontal(str, horizontalAlign, width, st)
+			str = alignTextHorizontal(s.r, str, horizontalAlign, width, st)
 		}
 	}
 
@@ -462,7 +475,7 @@ func (s Style) Render(strs ...string) string {
 		lines := strings.Split(str, "\n")
 
 		for i := range lines {
-			lines[i] = ansi.Truncate(lines[i], maxWidth, "")
+			lines[i] = s.r.truncate(lines[i], maxWidth)
 		}
 
 		str = strings.Join(lines, "\n")
@@ -477,7 +490,7 @@ func (s Style) Render(strs ...string) string {
 		}
 	}
 
-	return str
+	return s.r.finish(str)
 }
 
 func (s Style) maybeConvertTabs(str string) string {
@@ -490,6 +503,13 @@ func (s Style) maybeConvertTabs(str string) string {
 		return str
 	case 0:
 		return strings.ReplaceAll(str, "\t", "")
+	}
+
+	switch s.getAsTabMode() {
+	case TabStops:
+		return expandTabStops(s.getRenderer(), str, tw)
+	case ElasticTabs:
+		return expandElasticTabs(s.getRenderer(), str, tw)
 	default:
 		return strings.ReplaceAll(str, "\t", strings.Repeat(" ", tw))
 	}
@@ -516,7 +536,7 @@ func (s Style) applyMargins(str string, inline bool) string {
 
 	// Top/bottom margin
 	if !inline {
-		_, width := getLines(str)
+		_, width := s.r.getLines(str)
 		spaces := strings.Repeat(" ", width)
 
 		if topMargin > 0 {
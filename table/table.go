@@ -4,8 +4,7 @@ package table
 import (
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/x/ansi"
+	"github.com/rhystmorgan/lipgloss"
 )
 
 // HeaderRow denotes the header's row index used when rendering headers. Use
@@ -499,5 +498,5 @@ func (t *Table) truncateCell(cell string, rowIndex, colIndex int) string {
 	cellStyle := t.style(rowIndex, colIndex)
 
 	length := (cellWidth * height) - cellStyle.GetHorizontalPadding() - cellStyle.GetHorizontalMargins()
-	return ansi.Truncate(cell, length, "…")
+	return lipgloss.Truncate(cell, length, "…")
 }
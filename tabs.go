@@ -0,0 +1,123 @@
+package lipgloss
+
+import (
+	"strings"
+)
+
+// TabMode determines how tabs are converted to spaces at render time.
+type TabMode int
+
+// Available tab modes.
+const (
+	// TabSpaces replaces each tab with TabWidth spaces. This is the default.
+	TabSpaces TabMode = iota
+
+	// TabStops expands each tab to the next tab stop, relative to the visual
+	// column of the line. Tab stops are placed every TabWidth cells.
+	TabStops
+
+	// ElasticTabs aligns tab-separated cells across consecutive lines. Each
+	// column is as wide as its widest cell, rounded up to the next tab stop.
+	// A line with fewer cells ends the alignment of the columns it lacks.
+	ElasticTabs
+)
+
+// expandTabStops expands tabs to the next multiple of tw on each line. Escape
+// sequences don't count towards the column.
+func expandTabStops(r *Renderer, str string, tw int) string {
+	if !strings.ContainsRune(str, '\t') {
+		return str
+	}
+
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		if !strings.ContainsRune(line, '\t') {
+			continue
+		}
+
+		var (
+			b   strings.Builder
+			col int
+		)
+		cells := strings.Split(line, "\t")
+		for j, cell := range cells {
+			b.WriteString(cell)
+			col += r.stringWidth(cell)
+			if j < len(cells)-1 {
+				n := tw - col%tw
+				b.WriteString(strings.Repeat(" ", n))
+				col += n
+			}
+		}
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// expandElasticTabs aligns tab-separated cells across consecutive lines.
+func expandElasticTabs(r *Renderer, str string, tw int) string {
+	if !strings.ContainsRune(str, '\t') {
+		return str
+	}
+
+	lines := strings.Split(str, "\n")
+	cells := make([][]string, len(lines))
+	widths := make([][]int, len(lines))
+	for i, line := range lines {
+		cells[i] = strings.Split(line, "\t")
+		widths[i] = make([]int, len(cells[i]))
+		for j, cell := range cells[i] {
+			widths[i][j] = r.stringWidth(cell)
+		}
+	}
+
+	// A column is a run of consecutive lines that have a tab-terminated cell
+	// at the same index. Every cell in a run is padded to the same width.
+	columns := make([][]int, len(lines))
+	for i := range lines {
+		columns[i] = make([]int, len(cells[i])-1)
+	}
+	for j := 0; ; j++ {
+		found := false
+		for i := 0; i < len(lines); {
+			if len(columns[i]) <= j {
+				i++
+				continue
+			}
+			found = true
+
+			// Find the end of the run and its widest cell.
+			start, widest := i, 0
+			for ; i < len(lines) && len(columns[i]) > j; i++ {
+				widest = max(widest, widths[i][j])
+			}
+
+			// Round up to the next tab stop.
+			width := (widest/tw + 1) * tw
+			for k := start; k < i; k++ {
+				columns[k][j] = width
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	for i := range lines {
+		if len(columns[i]) == 0 {
+			continue
+		}
+
+		var b strings.Builder
+		for j, cell := range cells[i] {
+			b.WriteString(cell)
+			if j < len(columns[i]) {
+				b.WriteString(strings.Repeat(" ", columns[i][j]-widths[i][j]))
+			}
+		}
+		lines[i] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
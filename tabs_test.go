@@ -0,0 +1,79 @@
+package lipgloss
+
+import "testing"
+
+func TestTabModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    Style
+		input    string
+		expected string
+	}{
+		{
+			name:     "spaces",
+			style:    NewStyle(),
+			input:    "a\tbc\td",
+			expected: "a    bc    d",
+		},
+		{
+			name:     "tab stops",
+			style:    NewStyle().TabMode(TabStops),
+			input:    "a\tbc\td\nabcd\te",
+			expected: "a   bc  d\nabcd    e",
+		},
+		{
+			name:     "tab stops width",
+			style:    NewStyle().TabMode(TabStops).TabWidth(8),
+			input:    "ab\tc",
+			expected: "ab      c",
+		},
+		{
+			name:     "tab stops styled",
+			style:    NewStyle().TabMode(TabStops),
+			input:    "\x1b[1mab\x1b[0m\tc",
+			expected: "\x1b[1mab\x1b[0m  c",
+		},
+		{
+			name:     "tab stops wide",
+			style:    NewStyle().TabMode(TabStops),
+			input:    "你\tc",
+			expected: "你  c",
+		},
+		{
+			name:     "elastic",
+			style:    NewStyle().TabMode(ElasticTabs),
+			input:    "name\tsize\nREADME.md\t4 KB",
+			expected: "name        size\nREADME.md   4 KB",
+		},
+		{
+			name:  "elastic blocks",
+			style: NewStyle().TabMode(ElasticTabs),
+			input: "a\tb\tc\nabcde\tb\n\nx\ty",
+			expected: "a       b   c\n" +
+				"abcde   b    \n" +
+				"             \n" +
+				"x   y        ",
+		},
+		{
+			name:     "no conversion",
+			style:    NewStyle().TabMode(TabStops).TabWidth(NoTabConversion),
+			input:    "a\tb",
+			expected: "a\tb",
+		},
+		{
+			name:     "removed",
+			style:    NewStyle().TabMode(ElasticTabs).TabWidth(0),
+			input:    "a\tb",
+			expected: "ab",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.style.Render(tc.input)
+			if res != tc.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tc.expected, res)
+			}
+		})
+	}
+}
@@ -72,3 +72,32 @@ func DefaultIndenter(children Children, index int) string {
 	}
 	return "│  "
 }
+
+// ASCIIEnumerator enumerates a tree with ASCII characters only, for output
+// that may end up where box drawing characters can't be displayed.
+//
+// |-- Foo
+// |-- Bar
+// |-- Baz
+// `-- Qux.
+func ASCIIEnumerator(children Children, index int) string {
+	if children.Length()-1 == index {
+		return "`--"
+	}
+	return "|--"
+}
+
+// ASCIIIndenter indents a tree with ASCII characters only. It goes with
+// ASCIIEnumerator.
+//
+// |-- Foo
+// |-- Bar
+// |   |-- Qux
+// |   `-- Quux
+// `-- Baz.
+func ASCIIIndenter(children Children, index int) string {
+	if children.Length()-1 == index {
+		return "   "
+	}
+	return "|  "
+}
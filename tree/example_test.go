@@ -3,8 +3,8 @@ package tree_test
 import (
 	"fmt"
 
-	"github.com/charmbracelet/lipgloss/tree"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/rhystmorgan/lipgloss/tree"
 )
 
 // Leaf Examples
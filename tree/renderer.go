@@ -3,7 +3,7 @@ package tree
 import (
 	"strings"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/rhystmorgan/lipgloss"
 )
 
 // StyleFunc allows the tree to be styled per item.
@@ -27,8 +27,6 @@ func newRenderer() *renderer {
 				return lipgloss.NewStyle()
 			},
 		},
-		enumerator: DefaultEnumerator,
-		indenter:   DefaultIndenter,
 	}
 }
 
@@ -46,8 +44,13 @@ func (r *renderer) render(node Node, root bool, prefix string) string {
 	var strs []string
 	var maxLen int
 	children := node.Children()
-	enumerator := r.enumerator
-	indenter := r.indenter
+	enumerator, indenter := r.enumerator, r.indenter
+	if enumerator == nil {
+		enumerator = defaultEnumerator()
+	}
+	if indenter == nil {
+		indenter = defaultIndenter()
+	}
 
 	// print the root node name if its not empty.
 	if name := node.Value(); name != "" && root {
@@ -138,3 +141,27 @@ func (r *renderer) render(node Node, root bool, prefix string) string {
 	}
 	return strings.Join(strs, "\n")
 }
+
+// asciiOnly reports whether trees should be drawn with ASCII characters: when
+// the default renderer draws ASCII borders, or the terminal can't display box
+// drawing characters.
+func asciiOnly() bool {
+	r := lipgloss.DefaultRenderer()
+	return r.ASCIIBorders() || !r.Capabilities().UnicodeBoxDrawing
+}
+
+// defaultEnumerator returns the enumerator used when none is set.
+func defaultEnumerator() Enumerator {
+	if asciiOnly() {
+		return ASCIIEnumerator
+	}
+	return DefaultEnumerator
+}
+
+// defaultIndenter returns the indenter used when none is set.
+func defaultIndenter() Indenter {
+	if asciiOnly() {
+		return ASCIIIndenter
+	}
+	return DefaultIndenter
+}
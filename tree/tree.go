@@ -29,7 +29,7 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/charmbracelet/lipgloss"
+	"github.com/rhystmorgan/lipgloss"
 )
 
 // Node defines a node in a tree.
@@ -292,6 +292,10 @@ func (t *Tree) ItemStyleFunc(fn StyleFunc) *Tree {
 //
 //	tree.New().
 //		Enumerator(RoundedEnumerator)
+//
+// If no enumerator is set, DefaultEnumerator is used, or ASCIIEnumerator if
+// the default renderer draws ASCII borders or the terminal can't display box
+// drawing characters. The default indenter is chosen the same way.
 func (t *Tree) Enumerator(enum Enumerator) *Tree {
 	t.ensureRenderer().enumerator = enum
 	return t
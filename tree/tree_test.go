@@ -3,12 +3,12 @@ package tree_test
 import (
 	"testing"
 
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/list"
-	"github.com/charmbracelet/lipgloss/table"
-	"github.com/charmbracelet/lipgloss/tree"
 	"github.com/charmbracelet/x/exp/golden"
 	"github.com/muesli/termenv"
+	"github.com/rhystmorgan/lipgloss"
+	"github.com/rhystmorgan/lipgloss/list"
+	"github.com/rhystmorgan/lipgloss/table"
+	"github.com/rhystmorgan/lipgloss/tree"
 )
 
 func TestTree(t *testing.T) {
@@ -499,3 +499,21 @@ func TestTypes(t *testing.T) {
 
 	golden.RequireEqual(t, []byte(tree.String()))
 }
+
+func TestTreeASCIIBorders(t *testing.T) {
+	lipgloss.SetASCIIBorders(true)
+	defer lipgloss.SetASCIIBorders(false)
+
+	tr := tree.New().
+		Child(
+			"Foo",
+			tree.Root("Bar").
+				Child(
+					"Qux",
+					"Quux",
+				),
+			"Baz",
+		)
+
+	golden.RequireEqual(t, []byte(tr.String()))
+}
@@ -47,6 +47,12 @@ func (s Style) UnsetFaint() Style {
 	return s
 }
 
+// UnsetAutoForeground removes the auto foreground style rule, if set.
+func (s Style) UnsetAutoForeground() Style {
+	s.unset(autoForegroundKey)
+	return s
+}
+
 // UnsetForeground removes the foreground style rule, if set.
 func (s Style) UnsetForeground() Style {
 	s.unset(foregroundKey)
@@ -325,6 +331,12 @@ func (s Style) UnsetTabWidth() Style {
 	return s
 }
 
+// UnsetTabMode removes the tab mode style rule, if set.
+func (s Style) UnsetTabMode() Style {
+	s.unset(tabModeKey)
+	return s
+}
+
 // UnsetUnderlineSpaces removes the value set by UnderlineSpaces.
 func (s Style) UnsetUnderlineSpaces() Style {
 	s.unset(underlineSpacesKey)
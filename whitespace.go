@@ -3,7 +3,6 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -12,6 +11,10 @@ type whitespace struct {
 	re    *Renderer
 	style termenv.Style
 	chars string
+
+	// Background content for placement, and whether to render it faint.
+	content string
+	dim     bool
 }
 
 // newWhitespace creates a new whitespace renderer. The order of the options
@@ -45,17 +48,17 @@ func (w whitespace) render(width int) string {
 		if j >= len(r) {
 			j = 0
 		}
-		i += ansi.StringWidth(string(r[j]))
+		i += w.re.stringWidth(string(r[j]))
 	}
 
 	// Fill any extra gaps white spaces. This might be necessary if any runes
 	// are more than one cell wide, which could leave a one-rune gap.
-	short := width - ansi.StringWidth(b.String())
+	short := width - w.re.stringWidth(b.String())
 	if short > 0 {
 		b.WriteString(strings.Repeat(" ", short))
 	}
 
-	return w.style.Styled(b.String())
+	return w.re.plain(w.style.Styled(b.String()))
 }
 
 // WhitespaceOption sets a styling rule for rendering whitespace.
@@ -81,3 +84,27 @@ func WithWhitespaceChars(s string) WhitespaceOption {
 		w.chars = s
 	}
 }
+
+// WithBackgroundContent sets an existing rendered view to show in place of the
+// whitespace generated by Place, PlaceHorizontal and PlaceVertical. The
+// content is cut to the size of the placement area and padded with
+// whitespace if it's too small.
+//
+// Example:
+//
+//	view := lipgloss.Place(80, 24, lipgloss.Center, lipgloss.Center, dialog,
+//	    lipgloss.WithBackgroundContent(app.View()),
+//	)
+func WithBackgroundContent(bg string) WhitespaceOption {
+	return func(w *whitespace) {
+		w.content = bg
+	}
+}
+
+// WithDimmedBackground renders background content faint, keeping its
+// existing colors. It applies to WithBackgroundContent and PlaceOverlay.
+func WithDimmedBackground() WhitespaceOption {
+	return func(w *whitespace) {
+		w.dim = true
+	}
+}
@@ -0,0 +1,335 @@
+package lipgloss
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/cellbuf"
+	"github.com/clipperhouse/displaywidth"
+)
+
+// WidthMethod determines how the display width of text is measured. Terminals
+// differ in how they lay out characters such as emoji sequences and combining
+// marks, so the method should match the terminal being rendered to.
+type WidthMethod uint8
+
+// Available width methods.
+const (
+	// GraphemeWidth measures text by grapheme clusters, the way terminals
+	// that support grapheme clustering (mode 2027) lay it out. This is the
+	// default.
+	GraphemeWidth WidthMethod = iota
+
+	// WcWidth measures text rune by rune, the way wcwidth(3) does. Most
+	// terminals that don't support mode 2027 lay text out like this.
+	WcWidth
+)
+
+// WidthMethod returns the method used to measure text on the renderer.
+func (r *Renderer) WidthMethod() WidthMethod {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.widthMethod
+}
+
+// SetWidthMethod sets the method used to measure text on the renderer. It
+// affects Width, Style.Render, borders, placement and joins.
+//
+// This function is thread-safe.
+func (r *Renderer) SetWidthMethod(m WidthMethod) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.widthMethod = m
+}
+
+// SetWidthMethod sets the method used to measure text on the default
+// renderer.
+//
+// This function is thread-safe.
+func SetWidthMethod(m WidthMethod) {
+	DefaultRenderer().SetWidthMethod(m)
+}
+
+// AmbiguousWide returns whether East Asian characters of ambiguous width are
+// measured as two cells wide on the renderer.
+func (r *Renderer) AmbiguousWide() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.ambiguousWide
+}
+
+// SetAmbiguousWide sets whether East Asian characters of ambiguous width, such
+// as "○" and "±", are measured as two cells wide. Terminals configured for
+// CJK locales often render them this way. By default they're one cell wide.
+//
+// This function is thread-safe.
+func (r *Renderer) SetAmbiguousWide(v bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.ambiguousWide = v
+}
+
+// SetAmbiguousWide sets whether East Asian characters of ambiguous width are
+// measured as two cells wide on the default renderer.
+//
+// This function is thread-safe.
+func SetAmbiguousWide(v bool) {
+	DefaultRenderer().SetAmbiguousWide(v)
+}
+
+// Width returns the cell width of characters in the string, measured with
+// the renderer's width method. ANSI sequences are ignored. For multi-line
+// strings the width of the widest line is returned.
+func (r *Renderer) Width(str string) (width int) {
+	for _, l := range strings.Split(str, "\n") {
+		width = max(width, r.stringWidth(l))
+	}
+	return width
+}
+
+// stringWidth returns the cell width of a single line.
+func (r *Renderer) stringWidth(str string) int {
+	m := r.measure()
+	if m.isDefault() {
+		return ansi.StringWidth(str)
+	}
+
+	var (
+		width int
+		state byte
+	)
+	for len(str) > 0 {
+		seq, w, n, newState := ansi.DecodeSequence(str, state, nil)
+		if w > 0 {
+			width += m.width(seq)
+		}
+		state = newState
+		str = str[n:]
+	}
+	return width
+}
+
+// truncate truncates a line to the given width. Escape sequences are kept.
+func (r *Renderer) truncate(str string, width int) string {
+	m := r.measure()
+	if m.isDefault() {
+		return ansi.Truncate(str, width, "")
+	}
+
+	var (
+		b     strings.Builder
+		cur   int
+		state byte
+	)
+	for len(str) > 0 {
+		seq, w, n, newState := ansi.DecodeSequence(str, state, nil)
+		if w > 0 {
+			w = m.width(seq)
+		}
+		if w == 0 || cur+w <= width {
+			b.WriteString(seq)
+		}
+		cur += w
+		state = newState
+		str = str[n:]
+	}
+	return b.String()
+}
+
+// truncateLeft removes the given number of cells from the start of a line.
+// Escape sequences are kept. A wide character that straddles the cut is
+// kept whole.
+func (r *Renderer) truncateLeft(str string, n int) string {
+	m := r.measure()
+	if m.isDefault() {
+		return ansi.TruncateLeft(str, n, "")
+	}
+
+	var (
+		b     strings.Builder
+		cur   int
+		state byte
+	)
+	for len(str) > 0 {
+		seq, w, size, newState := ansi.DecodeSequence(str, state, nil)
+		if w > 0 {
+			w = m.width(seq)
+		}
+		cur += w
+		if w == 0 || cur > n {
+			b.WriteString(seq)
+		}
+		state = newState
+		str = str[size:]
+	}
+	return b.String()
+}
+
+// Truncate truncates each line of str to the given width, measured with the
+// renderer's width method, ending truncated lines with tail. Escape sequences
+// are kept.
+func (r *Renderer) Truncate(str string, width int, tail string) string {
+	lines := strings.Split(str, "\n")
+	for i, l := range lines {
+		if r.stringWidth(l) <= width {
+			continue
+		}
+		t := r.truncate(tail, width)
+		lines[i] = r.truncate(l, width-r.stringWidth(t)) + t
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Truncate truncates each line of str to the given width, measured with the
+// default renderer's width method. See [Renderer.Truncate].
+func Truncate(str string, width int, tail string) string {
+	return DefaultRenderer().Truncate(str, width, tail)
+}
+
+// Wrap wraps str so that no line is wider than the given width, measured
+// with the renderer's width method. Lines are broken at spaces, and words
+// wider than the width are broken where they overflow. Escape sequences are
+// kept.
+func (r *Renderer) Wrap(str string, width int) string {
+	m := r.measure()
+	switch {
+	case m.isDefault():
+		return ansi.Wrap(str, width, "")
+	case m.method == WcWidth && !m.ambiguousWide:
+		return ansi.WcWidth.Wrap(str, width, "")
+	case width < 1:
+		return str
+	}
+
+	lines := strings.Split(str, "\n")
+	for i, l := range lines {
+		lines[i] = wrapLine(m, l, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Wrap wraps str so that no line is wider than the given width, measured
+// with the default renderer's width method. See [Renderer.Wrap].
+func Wrap(str string, width int) string {
+	return DefaultRenderer().Wrap(str, width)
+}
+
+// wrapStyled wraps str like Wrap, but with the default width settings it
+// carries styles over to the wrapped lines, as Style.Render always has.
+func (r *Renderer) wrapStyled(str string, width int) string {
+	if r.measure().isDefault() {
+		return cellbuf.Wrap(str, width, "")
+	}
+	return r.Wrap(str, width)
+}
+
+// wrapLine wraps a single line at the given width, for width settings that
+// x/ansi and cellbuf don't support.
+func wrapLine(m measure, line string, limit int) string {
+	var (
+		b          strings.Builder
+		word       strings.Builder
+		space      strings.Builder
+		lineWidth  int
+		wordWidth  int
+		spaceWidth int
+		state      byte
+	)
+
+	// flushWord adds the pending word to the line, after the spaces before it,
+	// or on a new line if it doesn't fit.
+	flushWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		if lineWidth > 0 && lineWidth+spaceWidth+wordWidth > limit {
+			b.WriteByte('\n')
+			lineWidth = 0
+		} else {
+			b.WriteString(space.String())
+			lineWidth += spaceWidth
+		}
+		b.WriteString(word.String())
+		lineWidth += wordWidth
+		word.Reset()
+		space.Reset()
+		wordWidth, spaceWidth = 0, 0
+	}
+
+	for len(line) > 0 {
+		seq, w, n, newState := ansi.DecodeSequence(line, state, nil)
+		state = newState
+		line = line[n:]
+
+		switch {
+		case w == 0:
+			word.WriteString(seq)
+		case seq == " ":
+			flushWord()
+			space.WriteString(seq)
+			spaceWidth++
+		default:
+			w = m.width(seq)
+			if wordWidth+w > limit {
+				// Break words wider than the line.
+				flushWord()
+				if lineWidth > 0 {
+					b.WriteByte('\n')
+					lineWidth = 0
+				}
+				space.Reset()
+				spaceWidth = 0
+			}
+			word.WriteString(seq)
+			wordWidth += w
+		}
+	}
+	flushWord()
+	if lineWidth+spaceWidth <= limit {
+		b.WriteString(space.String())
+	}
+	return b.String()
+}
+
+// measure holds the settings used to measure text.
+type measure struct {
+	method        WidthMethod
+	ambiguousWide bool
+}
+
+func (r *Renderer) measure() measure {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return measure{r.widthMethod, r.ambiguousWide}
+}
+
+// isDefault reports whether text is measured the way x/ansi measures it.
+func (m measure) isDefault() bool {
+	return m.method == GraphemeWidth && !m.ambiguousWide
+}
+
+// width returns the width of a single grapheme cluster.
+func (m measure) width(cluster string) int {
+	opts := displaywidth.Options{EastAsianWidth: m.ambiguousWide}
+	if m.method == GraphemeWidth {
+		return opts.String(cluster)
+	}
+
+	var width int
+	for _, c := range cluster {
+		width += opts.Rune(c)
+	}
+	return width
+}
+
+// getLines splits a string into lines, additionally returning the size of
+// the widest line.
+func (r *Renderer) getLines(s string) (lines []string, widest int) {
+	lines = strings.Split(s, "\n")
+
+	for _, l := range lines {
+		widest = max(widest, r.stringWidth(l))
+	}
+
+	return lines, widest
+}
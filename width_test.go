@@ -0,0 +1,127 @@
+package lipgloss
+
+import (
+	"io"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestRendererWidthMethod(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    WidthMethod
+		ambiguous bool
+		str       string
+		expected  int
+	}{
+		{"ascii", GraphemeWidth, false, "hello", 5},
+		{"styled", GraphemeWidth, false, "\x1b[1mhello\x1b[0m", 5},
+		{"cjk", GraphemeWidth, false, "你好", 4},
+		{"zwj grapheme", GraphemeWidth, false, "👩‍💻", 2},
+		{"zwj wcwidth", WcWidth, false, "👩‍💻", 4},
+		{"ambiguous narrow", GraphemeWidth, false, "○±", 2},
+		{"ambiguous wide", GraphemeWidth, true, "○±", 4},
+		{"ambiguous wide wcwidth", WcWidth, true, "\x1b[31m○\x1b[0m±", 4},
+		{"multi-line", GraphemeWidth, false, "a\nbbb\ncc", 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(io.Discard)
+			r.SetWidthMethod(tc.method)
+			r.SetAmbiguousWide(tc.ambiguous)
+			if w := r.Width(tc.str); w != tc.expected {
+				t.Errorf("expected width %d, got %d", tc.expected, w)
+			}
+		})
+	}
+}
+
+func TestRendererWidthMethodLayout(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetWidthMethod(WcWidth)
+
+	s := r.NewStyle().Width(6)
+	if got, expected := s.Render("👩‍💻"), "👩‍💻  "; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	r.SetAmbiguousWide(true)
+	s = r.NewStyle().Border(NormalBorder())
+	if got := s.GetBorderLeftSize(); got != 2 {
+		t.Errorf("expected wide border, got size %d", got)
+	}
+	if got := r.Place(5, 1, Right, Top, "○"); got != "   ○" {
+		t.Errorf("expected %q, got %q", "   ○", got)
+	}
+	if got, expected := r.JoinHorizontal(Top, "○\n", "a\nb"), "○a\n  b"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if got, expected := r.JoinVertical(Right, "○", "ab"), "○\nab"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if got, expected := JoinVertical(Right, "○", "ab"), " ○\nab"; got != expected {
+		t.Errorf("expected the default renderer to be used, got %q", got)
+	}
+}
+
+func TestRendererWrap(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    WidthMethod
+		ambiguous bool
+		str       string
+		width     int
+		expected  string
+	}{
+		{"ascii", GraphemeWidth, false, "hello world", 5, "hello\nworld"},
+		{"ambiguous narrow", GraphemeWidth, false, "±± ±±", 5, "±± ±±"},
+		{"ambiguous wide", GraphemeWidth, true, "±± ±±", 4, "±±\n±±"},
+		{"ambiguous wide long word", GraphemeWidth, true, "±±±", 4, "±±\n±"},
+		{"ambiguous wide styled", GraphemeWidth, true, "\x1b[1m±±\x1b[0m ±", 4, "\x1b[1m±±\x1b[0m\n±"},
+		{"zwj wcwidth", WcWidth, false, "👩‍💻 ab", 4, "👩‍💻\nab"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRenderer(io.Discard)
+			r.SetWidthMethod(tc.method)
+			r.SetAmbiguousWide(tc.ambiguous)
+			if got := r.Wrap(tc.str, tc.width); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	r := NewRenderer(io.Discard)
+	r.SetAmbiguousWide(true)
+	if got, expected := r.NewStyle().Width(4).Render("±± ±±"), "±±\n±±"; got != expected {
+		t.Errorf("expected style to wrap to %q, got %q", expected, got)
+	}
+}
+
+func TestRendererTruncate(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	if got, expected := r.Truncate("hello\nhi", 4, "…"), "hel…\nhi"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	r.SetAmbiguousWide(true)
+	if got, expected := r.Truncate("±±±", 4, "…"), "±…"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestStyleGraphemesWidthMethod(t *testing.T) {
+	r := NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+	r.SetAmbiguousWide(true)
+	matched := r.NewStyle().Reverse(true)
+
+	// "±" is wide, so cell 1 is still the first grapheme.
+	got := StyleGraphemes("±a", []int{1}, matched, r.NewStyle(), WithCellIndices())
+	if expected := "\x1b[7m±\x1b[0ma"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,209 @@
+package lipgloss
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// maxPendingSequence is the longest incomplete escape sequence a Writer holds
+// back waiting for the rest of it. Longer ones are written as they are.
+const maxPendingSequence = 256
+
+// Writer rewrites the colors in the SGR (Select Graphic Rendition) sequences
+// written to it for a color profile, and writes the result to an underlying
+// writer. It's useful for writing strings that were rendered once, with more
+// colors than the destination supports, without rendering them again.
+//
+// Escape sequences may be split across writes: incomplete ones are held back
+// until the rest arrives, so call Flush when done writing.
+type Writer struct {
+	w       io.Writer
+	r       *Renderer
+	pending []byte
+}
+
+// NewWriter returns a Writer that converts colors to the given profile, the
+// way a Renderer with that profile does. With the Ascii profile, SGR
+// sequences are removed altogether.
+func NewWriter(w io.Writer, profile termenv.Profile) *Writer {
+	r := NewRenderer(w)
+	r.SetColorProfile(profile)
+	return r.NewWriter(w)
+}
+
+// NewWriter returns a Writer that converts colors to the renderer's color
+// profile, following its downsampling method and palette.
+func (r *Renderer) NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w, r: r}
+}
+
+// Write writes p to the underlying writer, with the colors in it rewritten.
+func (w *Writer) Write(p []byte) (int, error) {
+	var (
+		out []byte
+		buf = append(w.pending, p...)
+	)
+	w.pending = nil
+
+	for len(buf) > 0 {
+		i := bytes.IndexByte(buf, ansi.ESC)
+		if i < 0 {
+			out = append(out, buf...)
+			break
+		}
+		out = append(out, buf[:i]...)
+		buf = buf[i:]
+
+		if len(buf) < 2 { //nolint:mnd
+			w.pending = bytes.Clone(buf)
+			break
+		}
+		if buf[1] != '[' {
+			out = append(out, buf[0])
+			buf = buf[1:]
+			continue
+		}
+
+		end := bytes.IndexFunc(buf[2:], func(r rune) bool {
+			return r >= 0x40 && r <= 0x7e
+		})
+		if end < 0 {
+			if len(buf) > maxPendingSequence {
+				out = append(out, buf...)
+			} else {
+				w.pending = bytes.Clone(buf)
+			}
+			break
+		}
+		end += 2
+
+		params := string(buf[2:end])
+		if buf[end] == 'm' && !strings.ContainsAny(params, "<=>?") {
+			out = append(out, w.rewriteSGR(params)...)
+		} else {
+			out = append(out, buf[:end+1]...)
+		}
+		buf = buf[end+1:]
+	}
+
+	if len(out) > 0 {
+		if _, err := w.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any incomplete escape sequence held back to the underlying
+// writer as it is.
+func (w *Writer) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	_, err := w.w.Write(w.pending)
+	w.pending = nil
+	return err
+}
+
+// rewriteSGR returns the SGR sequence with the given parameters, with its
+// colors converted to the renderer's color profile.
+func (w *Writer) rewriteSGR(params string) string {
+	if w.r.ColorProfile() == termenv.Ascii {
+		return ""
+	}
+	if params == "" {
+		return ansi.ResetStyle
+	}
+
+	var (
+		out   []string
+		parts = strings.Split(params, ";")
+	)
+	for i := 0; i < len(parts); i++ {
+		code, sub, hasSub := strings.Cut(parts[i], ":")
+		if code != "38" && code != "48" && code != "58" {
+			out = append(out, parts[i])
+			continue
+		}
+
+		// Extended colors come as either 38;5;n and 38;2;r;g;b, or with
+		// colons as 38:5:n and 38:2:[colorspace:]r:g:b.
+		var args []string
+		if hasSub {
+			args = strings.Split(sub, ":")
+			if len(args) == 5 && args[0] == "2" { //nolint:mnd
+				args = append(args[:1], args[2:]...)
+			}
+		} else if i+1 < len(parts) {
+			n := 2 // 5;n
+			if parts[i+1] == "2" {
+				n = 4 // 2;r;g;b
+			}
+			args = parts[i+1 : min(i+1+n, len(parts))]
+			i += len(args)
+		}
+
+		if seq := w.colorParams(code, args); seq != "" {
+			out = append(out, seq)
+		}
+	}
+
+	if len(out) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(out, ";") + "m"
+}
+
+// colorParams returns the SGR parameters for an extended color given by its
+// arguments, converted to the renderer's color profile. It returns an empty
+// string if the color is invalid.
+func (w *Writer) colorParams(code string, args []string) string {
+	var value string
+	switch {
+	case len(args) == 2 && args[0] == "5": //nolint:mnd
+		value = args[1]
+	case len(args) == 4 && args[0] == "2": //nolint:mnd
+		var rgb [3]int
+		for i := range rgb {
+			v, err := strconv.Atoi(args[i+1])
+			if err != nil || v < 0 || v > 255 {
+				return ""
+			}
+			rgb[i] = v
+		}
+		value = fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+	default:
+		return ""
+	}
+
+	c := w.r.convertColor(value)
+	if c == nil {
+		return ""
+	}
+
+	switch code {
+	case "38":
+		return c.Sequence(false)
+	case "48":
+		return c.Sequence(true)
+	}
+
+	// Underline colors have no 16 color form.
+	switch c := c.(type) {
+	case termenv.ANSIColor:
+		return "58;5;" + strconv.Itoa(int(c))
+	case termenv.ANSI256Color:
+		return "58;5;" + strconv.Itoa(int(c))
+	case termenv.RGBColor:
+		cf := termenv.ConvertToRGB(c)
+		r, g, b := cf.RGB255()
+		return fmt.Sprintf("58;2;%d;%d;%d", r, g, b)
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package lipgloss
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestWriter(t *testing.T) {
+	tt := []struct {
+		name     string
+		profile  termenv.Profile
+		input    string
+		expected string
+	}{
+		{
+			name:     "truecolor unchanged",
+			profile:  termenv.TrueColor,
+			input:    "\x1b[1;38;2;255;0;0mhi\x1b[0m",
+			expected: "\x1b[1;38;2;255;0;0mhi\x1b[0m",
+		},
+		{
+			name:     "rgb to ansi256",
+			profile:  termenv.ANSI256,
+			input:    "\x1b[38;2;255;0;0;48;2;0;0;255mhi\x1b[m",
+			expected: "\x1b[38;5;196;48;5;21mhi\x1b[m",
+		},
+		{
+			name:     "ansi256 to ansi",
+			profile:  termenv.ANSI,
+			input:    "\x1b[4;38;5;196mhi\x1b[0m",
+			expected: "\x1b[4;91mhi\x1b[0m",
+		},
+		{
+			name:     "colon form",
+			profile:  termenv.ANSI256,
+			input:    "\x1b[38:2::255:0:0mhi",
+			expected: "\x1b[38;5;196mhi",
+		},
+		{
+			name:     "underline color",
+			profile:  termenv.ANSI,
+			input:    "\x1b[58;2;255;0;0mhi",
+			expected: "\x1b[58;5;9mhi",
+		},
+		{
+			name:     "ascii strips sgr",
+			profile:  termenv.Ascii,
+			input:    "\x1b[1;31mhi\x1b[0m \x1b]8;;https://charm.sh\x07link\x1b]8;;\x07",
+			expected: "hi \x1b]8;;https://charm.sh\x07link\x1b]8;;\x07",
+		},
+		{
+			name:     "other sequences unchanged",
+			profile:  termenv.ANSI,
+			input:    "\x1b[2J\x1b[?25l\x1b[>4;2mhi",
+			expected: "\x1b[2J\x1b[?25l\x1b[>4;2mhi",
+		},
+		{
+			name:     "invalid color dropped",
+			profile:  termenv.ANSI,
+			input:    "\x1b[1;38;2;300;0;0mhi",
+			expected: "\x1b[1mhi",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, tc.profile)
+			n, err := w.Write([]byte(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(tc.input) {
+				t.Errorf("expected %d bytes written, got %d", len(tc.input), n)
+			}
+			if res := buf.String(); res != tc.expected {
+				t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+					formatEscapes(tc.expected), formatEscapes(res))
+			}
+		})
+	}
+}
+
+func TestWriterSplitSequences(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, termenv.ANSI256)
+
+	input := "a\x1b[38;2;255;0;0mb\x1b[0mc\x1b"
+	for i := range len(input) {
+		if _, err := w.Write([]byte(input[i : i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := "a\x1b[38;5;196mb\x1b[0mc"
+	if res := buf.String(); res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if res := buf.String(); res != expected+"\x1b" {
+		t.Errorf("expected the held back escape to be flushed, got `%s`", formatEscapes(res))
+	}
+}
+
+func TestRendererWriter(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(&buf)
+	r.SetColorProfile(termenv.ANSI)
+
+	style := r.NewStyle().Foreground(Color("#ff8700"))
+	var want bytes.Buffer
+	tr := NewRenderer(&want)
+	tr.SetColorProfile(termenv.TrueColor)
+	rendered := tr.NewStyle().Foreground(Color("#ff8700")).Render("hi")
+
+	w := r.NewWriter(&buf)
+	if _, err := w.Write([]byte(rendered)); err != nil {
+		t.Fatal(err)
+	}
+	if res, expected := buf.String(), style.Render("hi"); res != expected {
+		t.Errorf("Expected:\n\n`%s`\n\nActual Output:\n\n`%s`\n\n",
+			formatEscapes(expected), formatEscapes(res))
+	}
+}